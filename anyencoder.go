@@ -0,0 +1,144 @@
+package golog
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// anyEncoder holds the encoders registered for a type
+type anyEncoder struct {
+	message func(m *Message, key string, val any)
+	writer  func(w Writer, val any)
+}
+
+var (
+	anyEncodersMtx sync.Mutex // serializes registrations
+	anyEncoders    sync.Map   // map[reflect.Type]*anyEncoder, entries are never mutated
+	hasAnyEncoders atomic.Bool
+)
+
+// RegisterAnyEncoder registers a function that logs values of type T
+// passed to Message.Any by calling typed Message methods with key,
+// so that types not implementing Loggable, like domain types from
+// other packages, can be logged as native values instead of JSON.
+//
+// Values of type T are also found behind pointers.
+// Message encoders are not used for elements of slices,
+// use RegisterAnyWriterEncoder for types that may be logged as slices.
+// The encoder must not call Message.Any with a value of type T
+// because that would recurse endlessly.
+//
+// Registered encoders take precedence over all other ways
+// Message.Any uses to log a value.
+// A previously registered message encoder for T is replaced.
+// Panics if T is an interface type.
+//
+// Example:
+//
+//	golog.RegisterAnyEncoder(func(m *golog.Message, key string, val decimal.Decimal) {
+//		m.Str(key, val.String())
+//	})
+func RegisterAnyEncoder[T any](encode func(m *Message, key string, val T)) {
+	storeAnyEncoder[T](func(e *anyEncoder) {
+		e.message = nil
+		if encode != nil {
+			e.message = func(m *Message, key string, val any) { encode(m, key, val.(T)) }
+		}
+	})
+}
+
+// RegisterAnyWriterEncoder registers a function that writes a value
+// of type T passed to Message.Any with exactly one call of a
+// Writer value method like Writer.WriteInt or Writer.WriteString.
+// The key or slice key has already been written when the encoder is called.
+//
+// Writer encoders are also used for elements of slices and arrays
+// and for values of type T behind pointers.
+// If both a message and a writer encoder are registered for T,
+// then the message encoder is used for values that are not slice elements.
+// A previously registered writer encoder for T is replaced.
+// Panics if T is an interface type.
+//
+// Example:
+//
+//	golog.RegisterAnyWriterEncoder(func(w golog.Writer, val money.Amount) {
+//		w.WriteInt(val.Cents())
+//	})
+func RegisterAnyWriterEncoder[T any](encode func(w Writer, val T)) {
+	storeAnyEncoder[T](func(e *anyEncoder) {
+		e.writer = nil
+		if encode != nil {
+			e.writer = func(w Writer, val any) { encode(w, val.(T)) }
+		}
+	})
+}
+
+// UnregisterAnyEncoders removes the message and writer encoders
+// registered for type T.
+func UnregisterAnyEncoders[T any]() {
+	storeAnyEncoder[T](func(e *anyEncoder) {
+		e.message = nil
+		e.writer = nil
+	})
+}
+
+func storeAnyEncoder[T any](modify func(*anyEncoder)) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("golog: can't register Message.Any encoder for interface type %s", t)) // Panic during setup is acceptable
+	}
+
+	anyEncodersMtx.Lock()
+	defer anyEncodersMtx.Unlock()
+
+	// Copy on write so lookups don't need a lock
+	var e anyEncoder
+	if existing, ok := anyEncoders.Load(t); ok {
+		e = *existing.(*anyEncoder)
+	}
+	modify(&e)
+	if e.message == nil && e.writer == nil {
+		anyEncoders.Delete(t)
+	} else {
+		anyEncoders.Store(t, &e)
+	}
+
+	hasAny := false
+	anyEncoders.Range(func(any, any) bool {
+		hasAny = true
+		return false
+	})
+	hasAnyEncoders.Store(hasAny)
+}
+
+// hasAnyEncoderFor reports if an encoder is registered for exactly type t.
+func hasAnyEncoderFor(t reflect.Type) bool {
+	if !hasAnyEncoders.Load() {
+		return false
+	}
+	_, ok := anyEncoders.Load(t)
+	return ok
+}
+
+// lookupAnyEncoder returns the encoder registered for the type of val
+// or for the type of a value val points to or wraps as interface
+// together with that value.
+// Returns nil if no encoder is registered for any of those types.
+func lookupAnyEncoder(val reflect.Value) (*anyEncoder, reflect.Value) {
+	if !hasAnyEncoders.Load() {
+		// Fast path without any registered encoders
+		return nil, val
+	}
+	for val.IsValid() {
+		if e, ok := anyEncoders.Load(val.Type()); ok {
+			return e.(*anyEncoder), val
+		}
+		if (val.Kind() != reflect.Pointer && val.Kind() != reflect.Interface) || val.IsNil() {
+			break
+		}
+		val = val.Elem()
+	}
+	return nil, val
+}
@@ -0,0 +1,102 @@
+package golog
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMoney struct {
+	cents    int64
+	currency string
+}
+
+type testID struct{ n int }
+
+func TestRegisterAnyEncoder(t *testing.T) {
+	RegisterAnyEncoder(func(m *Message, key string, val testMoney) {
+		m.Str(key, fmt.Sprintf("%d.%02d %s", val.cents/100, val.cents%100, val.currency))
+	})
+	RegisterAnyWriterEncoder(func(w Writer, val testID) {
+		w.WriteInt(int64(val.n))
+	})
+	t.Cleanup(func() {
+		UnregisterAnyEncoders[testMoney]()
+		UnregisterAnyEncoders[testID]()
+	})
+
+	var textBuf, jsonBuf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive,
+		NewTextWriterConfig(&textBuf, &Format{}, NoColorizer),
+		NewJSONWriterConfig(&jsonBuf, &Format{MessageKey: "message", LevelKey: "level"}),
+	)
+	log := NewLogger(config)
+
+	money := testMoney{cents: 1234, currency: "EUR"}
+	log.Info("Paid").
+		Any("amount", money).
+		Any("amountPtr", &money).
+		Any("id", testID{7}).
+		Any("ids", []testID{{1}, {2}}).
+		Any("idPtrs", []*testID{{3}, nil}).
+		Any("anys", []any{testID{4}, "x"}).
+		Log()
+
+	assert.Equal(t, ` |INFO | Paid amount="12.34 EUR" amountPtr="12.34 EUR" id=7 ids=[1,2] idPtrs=[3,nil] anys=[4,"x"]`+"\n", textBuf.String())
+	assert.Equal(t, `{"level":"INFO","message":"Paid","amount":"12.34 EUR","amountPtr":"12.34 EUR","id":7,"ids":[1,2],"idPtrs":[3,null],"anys":[4,"x"]}`+"\n", jsonBuf.String())
+
+	// Recorded attribs use the encoders when logged
+	textBuf.Reset()
+	log.With().Any("id", testID{8}).SubLogger().Info("Sub").Log()
+	assert.Equal(t, " |INFO | Sub id=8\n", textBuf.String())
+
+	UnregisterAnyEncoders[testMoney]()
+	UnregisterAnyEncoders[testID]()
+	textBuf.Reset()
+	log.Info("Unregistered").Any("id", testID{9}).Log()
+	assert.Equal(t, " |INFO | Unregistered id={}\n", textBuf.String())
+}
+
+func TestRegisterAnyEncoder_interface(t *testing.T) {
+	require.Panics(t, func() {
+		RegisterAnyWriterEncoder(func(w Writer, val fmt.Stringer) {})
+	})
+}
+
+func TestRegisterAnyEncoder_StructFields(t *testing.T) {
+	type S struct {
+		N int `json:"n"`
+	}
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer)))
+
+	log.Info("Before").StructFields(S{N: 1}).Log()
+
+	RegisterAnyWriterEncoder(func(w Writer, val int) { w.WriteString(fmt.Sprintf("#%d", val)) })
+	t.Cleanup(UnregisterAnyEncoders[int])
+
+	log.Info("After").StructFields(S{N: 2}).Log()
+
+	assert.Equal(t, " |INFO | Before n=1\n |INFO | After n=\"#2\"\n", buf.String())
+}
+
+func TestRegisterAnyEncoder_namespaceAndKeys(t *testing.T) {
+	RegisterAnyEncoder(func(m *Message, key string, val testMoney) {
+		m.Int64(key, val.cents).Str(key+"Currency", val.currency)
+	})
+	t.Cleanup(UnregisterAnyEncoders[testMoney])
+
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{}))).
+		WithNamespace("pay").
+		WithAttribKeyCollision(AttribCollisionFirstWins)
+
+	log.Info("").
+		Any("amount", testMoney{cents: 1234, currency: "EUR"}).
+		Any("amount", testMoney{cents: 1, currency: "USD"}).
+		Log()
+	assert.Equal(t, `{"pay.amount":1234,"pay.amountCurrency":"EUR"}`+"\n", buf.String())
+}
@@ -0,0 +1,312 @@
+package golog
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Attr returns a new Attrib with the concrete type
+// matching the Go type of val:
+//
+//   - bool and ~bool as Bool
+//   - signed integer types as Int
+//   - unsigned integer types as Uint
+//   - floating point types as Float
+//   - string and ~string as String
+//   - error as Error
+//   - time.Time as Time
+//   - time.Duration as Duration
+//   - [16]byte and named UUID types as UUID
+//   - json.RawMessage as JSON
+//   - slices of the above types as the corresponding slice attribs
+//   - nil interfaces as Nil
+//   - all other types as Any
+//
+// Named types are only converted by their kind if they have no
+// encoder registered with RegisterAnyEncoder and don't implement
+// one of the interfaces resolved by Message.Any before the kind
+// like slog.LogValuer, error, json.Marshaler, encoding.TextMarshaler,
+// or fmt.Stringer. Such types are logged as Any.
+//
+// Example:
+//
+//	ctx = golog.ContextWithAttribs(ctx, golog.Attr("userID", userID))
+func Attr[T any](key string, val T) Attrib {
+	switch x := any(val).(type) {
+	case nil:
+		return NewNil(key)
+	case bool:
+		return NewBool(key, x)
+	case int:
+		return NewInt(key, int64(x))
+	case int64:
+		return NewInt(key, x)
+	case uint64:
+		return NewUint(key, x)
+	case float64:
+		return NewFloat(key, x)
+	case string:
+		return NewString(key, x)
+	case time.Time:
+		return NewTime(key, x)
+	case time.Duration:
+		return NewDuration(key, x)
+	case [16]byte:
+		return NewUUID(key, x)
+	case json.RawMessage:
+		return NewJSON(key, x)
+	case error:
+		return NewError(key, x)
+	case []bool:
+		return NewBoolsCopy(key, x)
+	case []int:
+		return NewIntsCopy(key, x)
+	case []int64:
+		return NewIntsCopy(key, x)
+	case []uint64:
+		return NewUintsCopy(key, x)
+	case []float64:
+		return NewFloatsCopy(key, x)
+	case []string:
+		return NewStringsCopy(key, x)
+	case []error:
+		return NewErrorsCopy(key, x)
+	case []time.Time:
+		return NewTimesCopy(key, x)
+	case []time.Duration:
+		return NewDurationsCopy(key, x)
+	case [][16]byte:
+		return NewUUIDsCopy(key, x)
+	}
+
+	// Types logged as single value by Message.Any
+	// because of a registered encoder or an implemented interface
+	// like fmt.Stringer or slog.LogValuer keep that behavior
+	v := reflect.ValueOf(val)
+	if isSingleValueType(v.Type()) {
+		return NewAny(key, val)
+	}
+
+	// Named types and other sizes of the basic types
+	switch v.Kind() {
+	case reflect.Bool:
+		return NewBool(key, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NewInt(key, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return NewUint(key, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return NewFloat(key, v.Float())
+	case reflect.String:
+		return NewString(key, v.String())
+	case reflect.Array:
+		if uuid, ok := asUUID(v); ok {
+			return NewUUID(key, uuid)
+		}
+	case reflect.Slice:
+		if a := sliceAttr(key, v); a != nil {
+			return a
+		}
+	}
+	return NewAny(key, val)
+}
+
+// sliceAttr returns a slice attrib for slices
+// with elements of a named basic type
+// or nil if the element type is not supported.
+func sliceAttr(key string, v reflect.Value) Attrib {
+	switch v.Type().Elem().Kind() {
+	case reflect.Bool:
+		vals := make([]bool, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Bool()
+		}
+		return NewBools(key, vals)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		vals := make([]int64, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Int()
+		}
+		return NewInts(key, vals)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		vals := make([]uint64, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Uint()
+		}
+		return NewUints(key, vals)
+	case reflect.Float32, reflect.Float64:
+		vals := make([]float64, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).Float()
+		}
+		return NewFloats(key, vals)
+	case reflect.String:
+		vals := make([]string, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).String()
+		}
+		return NewStrings(key, vals)
+	}
+	return nil
+}
+
+// AttribValue returns the value of the attrib with the passed key
+// converted to T if possible.
+// A method with a type parameter like Attribs.Value[T]
+// is not possible in Go, so this is a function.
+//
+// Besides values that already have the type T
+// the following conversions are supported:
+//
+//   - Values of the same kind to named types like string to a ~string type
+//   - Numbers to other number types if the value fits without loss
+//   - UUIDs to strings formatted like "994d5800-afca-401f-9c2f-d9e3e106e9ef"
+//   - Strings to UUID types by parsing them
+//
+// Returns false for ok if there is no attrib with the key
+// or if its value can't be converted to T.
+func AttribValue[T any](attribs Attribs, key string) (val T, ok bool) {
+	attrib := attribs.Get(key)
+	if attrib == nil {
+		return val, false
+	}
+	return convertAttribValue[T](attrib)
+}
+
+func convertAttribValue[T any](attrib Attrib) (val T, ok bool) {
+	value := attrib.Value()
+	if val, ok = value.(T); ok {
+		return val, true
+	}
+	src := reflect.ValueOf(value)
+	if !src.IsValid() {
+		return val, false
+	}
+	target := reflect.TypeFor[T]()
+	dst := reflect.New(target).Elem()
+	switch {
+	case src.Kind() == target.Kind() && src.Type().ConvertibleTo(target):
+		dst.Set(src.Convert(target))
+
+	case isNumberKind(src.Kind()) && isNumberKind(target.Kind()):
+		if !setNumber(dst, src) {
+			return val, false
+		}
+
+	case target.Kind() == reflect.String && src.Type() == reflect.TypeFor[[16]byte]():
+		dst.SetString(FormatUUID(src.Interface().([16]byte)))
+
+	case src.Kind() == reflect.String && target.ConvertibleTo(reflect.TypeFor[[16]byte]()):
+		uuid, err := ParseUUID(src.String())
+		if err != nil {
+			return val, false
+		}
+		dst.Set(reflect.ValueOf(uuid).Convert(target))
+
+	default:
+		return val, false
+	}
+	return dst.Interface().(T), true
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64
+}
+
+// setNumber sets the number src to dst
+// and returns false if the value does not fit into dst.
+func setNumber(dst, src reflect.Value) bool {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := src.Int()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dst.OverflowInt(i) {
+				return false
+			}
+			dst.SetInt(i)
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(i))
+		default:
+			if i < 0 || dst.OverflowUint(uint64(i)) {
+				return false
+			}
+			dst.SetUint(uint64(i))
+		}
+	case reflect.Float32, reflect.Float64:
+		f := src.Float()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || dst.OverflowInt(int64(f)) {
+				return false
+			}
+			dst.SetInt(int64(f))
+		case reflect.Float32, reflect.Float64:
+			if dst.OverflowFloat(f) {
+				return false
+			}
+			dst.SetFloat(f)
+		default:
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || dst.OverflowUint(uint64(f)) {
+				return false
+			}
+			dst.SetUint(uint64(f))
+		}
+	default:
+		u := src.Uint()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if u > math.MaxInt64 || dst.OverflowInt(int64(u)) {
+				return false
+			}
+			dst.SetInt(int64(u))
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(u))
+		default:
+			if dst.OverflowUint(u) {
+				return false
+			}
+			dst.SetUint(u)
+		}
+	}
+	return true
+}
+
+// AttribKey is an attrib key with a value type
+// for creating attribs and retrieving their values
+// from Attribs and contexts type-safely.
+//
+// Example:
+//
+//	var userIDKey = golog.AttribKey[int64]("userID")
+//
+//	ctx = userIDKey.ContextWith(ctx, 42)
+//	userID, ok := userIDKey.FromContext(ctx)
+type AttribKey[T any] string
+
+// Attrib returns a new Attrib with the key and val using Attr.
+func (k AttribKey[T]) Attrib(val T) Attrib {
+	return Attr(string(k), val)
+}
+
+// Value returns the value of the attrib with the key
+// converted to T using AttribValue.
+func (k AttribKey[T]) Value(attribs Attribs) (val T, ok bool) {
+	return AttribValue[T](attribs, string(k))
+}
+
+// FromContext returns the value of the attrib with the key
+// from the attribs added to ctx with ContextWithAttribs
+// converted to T using AttribValue.
+func (k AttribKey[T]) FromContext(ctx context.Context) (val T, ok bool) {
+	return AttribValue[T](AttribsFromContext(ctx), string(k))
+}
+
+// ContextWith returns a new context with an attrib
+// for the key and val added to the attribs of ctx.
+func (k AttribKey[T]) ContextWith(ctx context.Context, val T) context.Context {
+	return ContextWithAttribs(ctx, k.Attrib(val))
+}
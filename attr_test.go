@@ -0,0 +1,166 @@
+package golog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testAttrString string
+	testAttrInt    int16
+	testAttrUUID   [16]byte
+)
+
+func TestAttr(t *testing.T) {
+	uuid := MustParseUUID("a547276f-b02b-4e7d-b67e-c6deb07567da")
+	now := time.Now()
+	err := errors.New("error")
+	tests := []struct {
+		attrib Attrib
+		want   Attrib
+	}{
+		{Attr("k", true), NewBool("k", true)},
+		{Attr("k", 1), NewInt("k", 1)},
+		{Attr("k", int8(-1)), NewInt("k", -1)},
+		{Attr("k", testAttrInt(2)), NewInt("k", 2)},
+		{Attr("k", uint32(3)), NewUint("k", 3)},
+		{Attr("k", float32(0.5)), NewFloat("k", 0.5)},
+		{Attr("k", "str"), NewString("k", "str")},
+		{Attr("k", testAttrString("str")), NewString("k", "str")},
+		{Attr("k", err), NewError("k", err)},
+		{Attr[error]("k", nil), NewNil("k")},
+		{Attr("k", now), NewTime("k", now)},
+		{Attr("k", time.Second), NewDuration("k", time.Second)},
+		{Attr("k", uuid), NewUUID("k", uuid)},
+		{Attr("k", testAttrUUID(uuid)), NewUUID("k", uuid)},
+		{Attr("k", json.RawMessage(`{}`)), NewJSON("k", json.RawMessage(`{}`))},
+		{Attr("k", []int{1, 2}), NewInts("k", []int64{1, 2})},
+		{Attr("k", []testAttrInt{1, 2}), NewInts("k", []int64{1, 2})},
+		{Attr("k", []testAttrString{"a"}), NewStrings("k", []string{"a"})},
+		{Attr("k", [][16]byte{uuid}), NewUUIDs("k", [][16]byte{uuid})},
+		{Attr("k", map[string]int{"a": 1}), NewAny("k", map[string]int{"a": 1})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.attrib)
+		})
+	}
+}
+
+func TestAttribValue(t *testing.T) {
+	uuid := MustParseUUID("a547276f-b02b-4e7d-b67e-c6deb07567da")
+	attribs := Attribs{
+		NewInt("int", 300),
+		NewInt("neg", -1),
+		NewFloat("float", 2),
+		NewFloat("fraction", 2.5),
+		NewString("str", "str"),
+		NewString("uuidStr", FormatUUID(uuid)),
+		NewUUID("uuid", uuid),
+		NewInts("ints", []int64{1, 2}),
+	}
+
+	i64, ok := AttribValue[int64](attribs, "int")
+	assert.True(t, ok)
+	assert.Equal(t, int64(300), i64)
+
+	i, ok := AttribValue[int](attribs, "float")
+	assert.True(t, ok)
+	assert.Equal(t, 2, i)
+
+	_, ok = AttribValue[int](attribs, "fraction")
+	assert.False(t, ok, "float with fraction as int")
+
+	_, ok = AttribValue[int8](attribs, "int")
+	assert.False(t, ok, "overflow")
+
+	_, ok = AttribValue[uint](attribs, "neg")
+	assert.False(t, ok, "negative as uint")
+
+	u8, ok := AttribValue[uint16](attribs, "int")
+	assert.True(t, ok)
+	assert.Equal(t, uint16(300), u8)
+
+	named, ok := AttribValue[testAttrString](attribs, "str")
+	assert.True(t, ok)
+	assert.Equal(t, testAttrString("str"), named)
+
+	str, ok := AttribValue[string](attribs, "uuid")
+	assert.True(t, ok)
+	assert.Equal(t, FormatUUID(uuid), str)
+
+	_, ok = AttribValue[string](attribs, "int")
+	assert.False(t, ok, "int as string")
+
+	parsed, ok := AttribValue[[16]byte](attribs, "uuidStr")
+	assert.True(t, ok)
+	assert.Equal(t, uuid, parsed)
+
+	namedUUID, ok := AttribValue[testAttrUUID](attribs, "uuid")
+	assert.True(t, ok)
+	assert.Equal(t, testAttrUUID(uuid), namedUUID)
+
+	_, ok = AttribValue[[16]byte](attribs, "str")
+	assert.False(t, ok, "invalid UUID string")
+
+	ints, ok := AttribValue[[]int64](attribs, "ints")
+	assert.True(t, ok)
+	assert.Equal(t, []int64{1, 2}, ints)
+
+	_, ok = AttribValue[int](attribs, "missing")
+	assert.False(t, ok)
+}
+
+func TestAttribKey(t *testing.T) {
+	const userIDKey AttribKey[int64] = "userID"
+
+	ctx := userIDKey.ContextWith(context.Background(), 42)
+	require.IsType(t, &Int{}, AttribsFromContext(ctx).Get("userID"))
+
+	userID, ok := userIDKey.FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, int64(42), userID)
+
+	userID, ok = userIDKey.Value(Attribs{userIDKey.Attrib(7)})
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), userID)
+
+	_, ok = userIDKey.FromContext(context.Background())
+	assert.False(t, ok)
+}
+
+func TestGetRequestUUIDFromContext_string(t *testing.T) {
+	uuid := MustParseUUID("a547276f-b02b-4e7d-b67e-c6deb07567da")
+	ctx := ContextWithRequestID(context.Background(), FormatUUID(uuid))
+
+	requestID, ok := GetRequestUUIDFromContext(ctx)
+	assert.True(t, ok, "UUID stored as string")
+	assert.Equal(t, uuid, requestID)
+	assert.Equal(t, uuid, GetOrCreateRequestUUIDFromContext(ctx))
+}
+
+type testAttrStringer int
+
+func (s testAttrStringer) String() string { return "stringer" }
+
+func TestAttr_singleValueTypes(t *testing.T) {
+	a := Attr("k", testAttrStringer(1))
+	assert.IsType(t, &Any{}, a, "fmt.Stringer is logged as Any")
+	assert.Equal(t, `"k":"stringer"`, string(a.AppendJSON(nil)))
+
+	assert.IsType(t, &Any{}, Attr("k", testSecret("password")), "slog.LogValuer is logged as Any")
+	type plainInt int
+	assert.IsType(t, &Int{}, Attr("k", plainInt(1)), "named int without interfaces")
+}
+
+func TestGetRequestIDFromContext_otherTypes(t *testing.T) {
+	ctx := ContextWithAttribs(context.Background(), NewInt("requestID", 42))
+	assert.Equal(t, "42", GetRequestIDFromContext(ctx))
+	assert.Equal(t, "", GetRequestIDFromContext(context.Background()))
+}
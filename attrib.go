@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/domonda/go-encjson"
@@ -50,6 +51,8 @@ type SliceAttrib interface {
 var (
 	_ Attrib      = &Nil{}
 	_ Attrib      = &Any{}
+	_ SliceAttrib = &Anys{}
+	_ Attrib      = &Lazy{}
 	_ Attrib      = &Bool{}
 	_ SliceAttrib = &Bools{}
 	_ Attrib      = &Int{}
@@ -62,6 +65,8 @@ var (
 	_ SliceAttrib = &Strings{}
 	_ Attrib      = &Error{}
 	_ SliceAttrib = &Errors{}
+	_ Attrib      = &Duration{}
+	_ SliceAttrib = &Durations{}
 	_ Attrib      = &UUID{}
 	_ SliceAttrib = &UUIDs{}
 	_ Attrib      = &JSON{}
@@ -136,6 +141,7 @@ func (a *Any) Log(m *Message) {
 }
 
 func (a Any) AppendJSON(buf []byte) []byte {
+	start := len(buf)
 	buf = encjson.AppendKey(buf, a.key)
 	switch v := a.val.(type) {
 	case nil:
@@ -172,18 +178,20 @@ func (a Any) AppendJSON(buf []byte) []byte {
 		return encjson.AppendStringBytes(buf, v)
 	case time.Time:
 		return encjson.AppendTime(buf, v, time.RFC3339)
+	case time.Duration:
+		return encjson.AppendInt(buf, int64(v))
 	case [16]byte:
 		return encjson.AppendUUID(buf, v)
 	case json.RawMessage:
 		buf = append(buf, v...)
+	case *ErrorChain:
+		return v.AppendJSON(buf)
+	case Source:
+		return v.AppendJSON(buf)
 	default:
-		// Slower escape hatch for all other types
-		j, err := json.Marshal(v)
-		if err == nil {
-			buf = append(buf, j...)
-		} else {
-			buf = encjson.AppendString(buf, err.Error())
-		}
+		// Slower path for all other types writing the value
+		// exactly like Message.Any does with a JSONWriter
+		return appendAnyJSON(buf[:start], a.key, v, nil, nil)
 	}
 	return buf
 }
@@ -192,6 +200,126 @@ func (a *Any) String() string {
 	return fmt.Sprintf("Any{%q: %s}", a.key, a.ValueString())
 }
 
+// Anys
+
+// Anys holds a slice of values with different types
+// like a slice written to a Writer with mixed value types.
+type Anys struct {
+	key  string
+	vals []any
+}
+
+func NewAnys(key string, vals []any) *Anys {
+	a := anysPool.GetOrNew()
+	a.key = key
+	a.vals = vals
+	return a
+}
+
+func (a *Anys) Clone() Attrib {
+	return NewAnys(a.key, a.vals)
+}
+
+func (a *Anys) Free() {
+	anysPool.ZeroAndPutBack(a)
+}
+
+func (a *Anys) Key() string         { return a.key }
+func (a *Anys) Value() any          { return a.vals }
+func (a *Anys) ValueString() string { return fmt.Sprintf("%#v", a.vals) }
+
+func (a *Anys) Log(m *Message) {
+	m.Any(a.key, a.vals)
+}
+
+func (a *Anys) AppendJSON(buf []byte) []byte {
+	return appendAnyJSON(buf, a.key, a.vals, nil, nil)
+}
+
+func (a *Anys) String() string {
+	return fmt.Sprintf("Anys{%q: %s}", a.key, a.ValueString())
+}
+
+func (a *Anys) Len() int { return len(a.vals) }
+
+// Lazy
+
+// Lazy is an Attrib with a value that is computed by a function
+// only when the attrib is written by an active message.
+// The function is called at most once, also for clones of the attrib,
+// and the result is reused for all writers and messages.
+type Lazy struct {
+	key string
+	val *lazyValue
+}
+
+type lazyValue struct {
+	once sync.Once
+	f    func() any
+	val  any
+}
+
+func (v *lazyValue) get() any {
+	v.once.Do(func() {
+		if v.f != nil {
+			v.val = v.f()
+			v.f = nil
+		}
+	})
+	return v.val
+}
+
+// NewLazy returns a Lazy attrib with a value
+// that will be computed by calling f.
+func NewLazy(key string, f func() any) *Lazy {
+	a := lazyPool.GetOrNew()
+	a.key = key
+	a.val = &lazyValue{f: f}
+	return a
+}
+
+func (a *Lazy) Clone() Attrib {
+	c := lazyPool.GetOrNew()
+	c.key = a.key
+	c.val = a.val // share evaluation
+	return c
+}
+
+func (a *Lazy) Free() {
+	lazyPool.ZeroAndPutBack(a)
+}
+
+func (a *Lazy) Key() string { return a.key }
+
+// Value returns the computed value
+// calling the function if that has not happened yet.
+func (a *Lazy) Value() any          { return a.val.get() }
+func (a *Lazy) ValueString() string { return fmt.Sprintf("%#v", a.Value()) }
+
+// Log logs the computed value to m or records the
+// unevaluated attrib if m is an attrib recorder.
+func (a *Lazy) Log(m *Message) {
+	key := a.key
+	if m.skipKey(&key) {
+		return
+	}
+	if m.IsAttribRecorder() {
+		c := a.Clone().(*Lazy)
+		c.key = key
+		m.attribs.Add(c)
+		return
+	}
+	m.writeAny(key, a.Value())
+}
+
+func (a *Lazy) AppendJSON(buf []byte) []byte {
+	return appendAnyJSON(buf, a.key, a.Value(), nil, nil)
+}
+
+func (a *Lazy) String() string {
+	return fmt.Sprintf("Lazy{%q: %s}", a.key, a.ValueString())
+}
+
 // Bool
 
 type Bool struct {
@@ -841,6 +969,93 @@ func (a *Times) String() string {
 
 func (a *Times) Len() int { return len(a.vals) }
 
+// Duration
+
+type Duration struct {
+	key string
+	val time.Duration
+}
+
+func NewDuration(key string, val time.Duration) *Duration {
+	a := durationPool.GetOrNew()
+	a.key = key
+	a.val = val
+	return a
+}
+
+func (a *Duration) Clone() Attrib {
+	return NewDuration(a.key, a.val)
+}
+
+func (a *Duration) Free() {
+	durationPool.ZeroAndPutBack(a)
+}
+
+func (a *Duration) Key() string         { return a.key }
+func (a *Duration) Value() any          { return a.val }
+func (a *Duration) ValueString() string { return a.val.String() }
+
+func (a *Duration) Log(m *Message) {
+	m.Duration(a.key, a.val)
+}
+
+func (a *Duration) AppendJSON(buf []byte) []byte {
+	return encjson.AppendString(encjson.AppendKey(buf, a.key), a.val.String())
+}
+
+func (a *Duration) String() string {
+	return fmt.Sprintf("Duration{%q: %s}", a.key, a.val)
+}
+
+type Durations struct {
+	key  string
+	vals []time.Duration
+}
+
+func NewDurations(key string, vals []time.Duration) *Durations {
+	a := durationsPool.GetOrNew()
+	a.key = key
+	a.vals = vals
+	return a
+}
+
+func NewDurationsCopy(key string, vals []time.Duration) *Durations {
+	if vals == nil {
+		return NewDurations(key, nil)
+	}
+	return NewDurations(key, slices.Clone(vals))
+}
+
+func (a *Durations) Clone() Attrib {
+	return NewDurations(a.key, a.vals)
+}
+
+func (a *Durations) Free() {
+	durationsPool.ZeroAndPutBack(a)
+}
+
+func (a *Durations) Key() string         { return a.key }
+func (a *Durations) Value() any          { return a.vals }
+func (a *Durations) ValueString() string { return fmt.Sprint(a.vals) }
+
+func (a *Durations) Log(m *Message) {
+	m.Durations(a.key, a.vals)
+}
+
+func (a *Durations) AppendJSON(buf []byte) []byte {
+	buf = encjson.AppendArrayStart(encjson.AppendKey(buf, a.key))
+	for _, val := range a.vals {
+		buf = encjson.AppendString(buf, val.String())
+	}
+	return encjson.AppendArrayEnd(buf)
+}
+
+func (a *Durations) String() string {
+	return fmt.Sprintf("Durations{%q: %s}", a.key, a.ValueString())
+}
+
+func (a *Durations) Len() int { return len(a.vals) }
+
 // UUID
 
 type UUID struct {
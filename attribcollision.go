@@ -0,0 +1,122 @@
+package golog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// AttribCollisionPolicy decides what happens when an attrib
+// is logged with a key that was already logged for a message.
+//
+// Logger attribs are logged before context attribs
+// and those are logged before the attribs added
+// with the methods of a message.
+type AttribCollisionPolicy int
+
+const (
+	// AttribCollisionLoggerWins is the default policy that ignores
+	// context and message attribs with the keys of logger attribs.
+	// Other keys are not checked, so context and message attribs
+	// with the same key are all logged.
+	// Attribs recorded with Logger.With are handled like AttribCollisionFirstWins.
+	AttribCollisionLoggerWins AttribCollisionPolicy = iota
+
+	// AttribCollisionFirstWins ignores attribs with keys that were already logged.
+	// This means that logger attribs win over context attribs
+	// and those win over attribs added to a message.
+	AttribCollisionFirstWins
+
+	// AttribCollisionLastWins replaces already logged attribs
+	// with later attribs with the same key
+	// that are logged in the order they were added.
+	// This means that message attribs win over context attribs
+	// and those win over logger attribs.
+	// The attribs of a message are recorded and only written
+	// when the message is logged, which needs more allocations.
+	AttribCollisionLastWins
+
+	// AttribCollisionSuffix logs attribs with keys that were already logged
+	// with the suffix "_2", or "_3" and so on if that key was also logged.
+	AttribCollisionSuffix
+
+	// AttribCollisionError calls ErrorHandler with an error
+	// for attribs with keys that were already logged and ignores them.
+	AttribCollisionError
+)
+
+// String implements the fmt.Stringer interface.
+func (p AttribCollisionPolicy) String() string {
+	switch p {
+	case AttribCollisionLoggerWins:
+		return "LoggerWins"
+	case AttribCollisionFirstWins:
+		return "FirstWins"
+	case AttribCollisionLastWins:
+		return "LastWins"
+	case AttribCollisionSuffix:
+		return "Suffix"
+	case AttribCollisionError:
+		return "Error"
+	}
+	return fmt.Sprintf("AttribCollisionPolicy(%d)", int(p))
+}
+
+// skipKey returns true if an attrib with the passed key must not be logged
+// because the message is nil or because of the collision policy of the logger.
+// The key is prefixed with the namespace of the message
+// and may be changed for AttribCollisionSuffix.
+func (m *Message) skipKey(key *string) bool {
+	if m == nil {
+		return true
+	}
+	if m.namespace != "" {
+		*key = m.namespace + *key
+	}
+	if m.hasKey(*key) {
+		switch m.collision {
+		case AttribCollisionLastWins:
+			if !m.IsAttribRecorder() {
+				return true // Already written
+			}
+			i := m.attribs.Index(*key)
+			m.attribs[i].Free()
+			m.attribs = slices.Delete(m.attribs, i, i+1)
+			m.keys.remove(*key)
+		case AttribCollisionSuffix:
+			base := *key
+			for n := 2; m.hasKey(*key); n++ {
+				*key = base + "_" + strconv.Itoa(n)
+			}
+		case AttribCollisionError:
+			if ErrorHandler != nil {
+				ErrorHandler(fmt.Errorf("golog: attrib key %q already logged for message %q", *key, m.text))
+			}
+			return true
+		default:
+			return true
+		}
+	}
+	if m.collision != AttribCollisionLoggerWins || !m.loggerKeysOnly {
+		m.keys.add(*key)
+	}
+	return false
+}
+
+// hasKey returns true if an attrib with the key was
+// recorded or written for the message.
+func (m *Message) hasKey(key string) bool {
+	return m.keys.has(key)
+}
+
+// writeRecordedAttribs writes the attribs recorded
+// for AttribCollisionLastWins to the deferred writers.
+func (m *Message) writeRecordedAttribs() {
+	attribs := m.attribs
+	m.attribs = nil
+	m.writers, m.deferredWriters = m.deferredWriters, nil
+	m.namespace = "" // Recorded keys already have the namespace
+	m.keys.reset()   // Write the recorded keys again
+	attribs.Log(m)
+	attribs.Free()
+}
@@ -0,0 +1,95 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttribKeyCollision(t *testing.T) {
+	for _, tt := range []struct {
+		policy AttribCollisionPolicy
+		want   string
+	}{
+		{AttribCollisionLoggerWins, `{"a":"logger","b":"ctx","b":"msg","c":1,"c":2}`},
+		{AttribCollisionFirstWins, `{"a":"logger","b":"ctx","c":1}`},
+		{AttribCollisionLastWins, `{"a":"msg","b":"msg","c":2}`},
+		{AttribCollisionSuffix, `{"a":"logger","b":"ctx","a_2":"ctx","a_3":"msg","b_2":"msg","c":1,"c_2":2}`},
+		{AttribCollisionError, `{"a":"logger","b":"ctx","c":1}`},
+	} {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			var errs []error
+			defer func(handler func(error)) { ErrorHandler = handler }(ErrorHandler)
+			ErrorHandler = func(err error) { errs = append(errs, err) }
+
+			var buf bytes.Buffer
+			log := NewLogger(
+				NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})),
+				NewString("a", "logger"),
+			).WithAttribKeyCollision(tt.policy)
+			assert.Equal(t, tt.policy, log.AttribKeyCollision())
+			ctx := ContextWithAttribs(context.Background(), NewString("b", "ctx"), NewString("a", "ctx"))
+
+			log.InfoCtx(ctx, "").
+				Str("a", "msg").
+				Str("b", "msg").
+				Int("c", 1).
+				Int("c", 2).
+				Log()
+
+			assert.Equal(t, tt.want+"\n", buf.String())
+			if tt.policy == AttribCollisionError {
+				require.Len(t, errs, 4)
+				assert.EqualError(t, errs[0], `golog: attrib key "a" already logged for message ""`)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestAttribKeyCollision_With(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(
+		NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})),
+		NewString("a", "logger"),
+	)
+
+	subLog := log.WithAttribKeyCollision(AttribCollisionLastWins).With().Str("a", "sub").Error("err", errors.New("error")).SubLogger()
+	assert.Equal(t, Attribs{NewString("a", "sub"), NewError("err", errors.New("error"))}, subLog.Attribs())
+
+	subLog.Info("").Str("err", "replaced").Nil("x").Log()
+	assert.Equal(t, `{"a":"sub","err":"replaced","x":null}`+"\n", buf.String())
+
+	assert.Equal(t, AttribCollisionLastWins, subLog.AttribKeyCollision(), "inherited by sub-logger")
+
+	subLog = log.WithAttribKeyCollision(AttribCollisionSuffix).With().Str("a", "sub").SubLogger()
+	assert.Equal(t, Attribs{NewString("a", "logger"), NewString("a_2", "sub")}, subLog.Attribs())
+}
+
+func TestLogger_WithNamespace(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(
+		NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})),
+		NewString("service", "api"),
+	)
+	assert.Equal(t, "", log.Namespace())
+
+	dbLog := log.WithNamespace("db")
+	assert.Equal(t, "db.", dbLog.Namespace())
+	assert.Equal(t, log, log.WithNamespace(""))
+
+	ctx := ContextWithAttribs(context.Background(), NewString("requestID", "1"))
+	dbLog.InfoCtx(ctx, "").Str("query", "SELECT").Any("rows", []int{1}).Bytes("nil", nil).Log()
+	assert.Equal(t, `{"service":"api","requestID":"1","db.query":"SELECT","db.rows":[1],"db.nil":null}`+"\n", buf.String())
+
+	buf.Reset()
+	pgLog := dbLog.With().Str("host", "localhost").SubLogger().WithNamespace("pg")
+	assert.Equal(t, "db.pg.", pgLog.Namespace())
+	pgLog.Info("").Int("conns", 2).Log()
+	assert.Equal(t, `{"service":"api","db.host":"localhost","db.pg.conns":2}`+"\n", buf.String())
+}
@@ -2,7 +2,10 @@ package golog
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 
 	"github.com/domonda/go-encjson"
 )
@@ -14,8 +17,17 @@ var (
 // Attribs is a Attrib slice with methods to manage and log them.
 // Usually only one Attrib with a given key is present in the slice,
 // but this is not enforced.
+// Use Replace, Remove, and CloneAndMerge to manage attribs by key.
+//
 // A slice is used instead of a map to preserve the order
 // of attributes and to maximize allocation performance.
+// Because the slice can be modified directly, no key index
+// can be kept for it, so single lookups with Get, Has, and Index
+// search the slice linearly and Add doesn't check for existing keys.
+// Methods handling many keys at once like Remove, Replace,
+// and CloneAndMerge, and Message for the keys of logged attribs,
+// build an index for large sets instead of repeated linear searches
+// and don't add attribs with keys that are already present.
 //
 // Attribs implements the Loggable interface by logging
 // the attributes in the slice in the given order.
@@ -171,17 +183,157 @@ func (a Attribs) CloneAndAppendNonExistingCloned(b Attribs) Attribs {
 	if len(a) == 0 {
 		return b.Clone()
 	}
+	return a.CloneAndMerge(b, AttribCollisionFirstWins)
+}
+
+// CloneAndMerge returns clones of the attribs of a
+// merged with clones of the attribs of b
+// where attribs of b with keys already present
+// are handled according to policy
+// like attribs logged for a message.
+// With AttribCollisionLastWins replaced attribs
+// are removed and the attribs of b appended.
+// Attribs of b with keys renamed by AttribCollisionSuffix
+// are logged and marshalled as JSON using their Value.
+//
+// The keys are looked up in an index for large sets,
+// so merging doesn't become quadratic.
+// The result is a new slice and the slices a and b
+// are not modified.
+func (a Attribs) CloneAndMerge(b Attribs, policy AttribCollisionPolicy) Attribs {
 	if len(b) == 0 {
 		return a.Clone()
 	}
-	result := attribsPool.GetOrMake(len(a), len(a)+len(b))
-	copy(result, a)
-	for _, bAttrib := range b {
-		if !a.Has(bAttrib.Key()) {
-			result = append(result, bAttrib.Clone())
+	merged := attribsPool.GetOrMake(0, len(a)+len(b))
+
+	if policy == AttribCollisionLastWins {
+		// Find the last attrib of b for every key
+		var winners keySet
+		last := make([]bool, len(b))
+		for i := len(b) - 1; i >= 0; i-- {
+			if key := b[i].Key(); !winners.has(key) {
+				winners.add(key)
+				last[i] = true
+			}
+		}
+		for _, attrib := range a {
+			if !winners.has(attrib.Key()) {
+				merged = append(merged, attrib.Clone())
+			}
+		}
+		for i, attrib := range b {
+			if last[i] {
+				merged = append(merged, attrib.Clone())
+			}
+		}
+		return merged
+	}
+
+	var keys keySet
+	for _, attrib := range a {
+		merged = append(merged, attrib.Clone())
+		keys.add(attrib.Key())
+	}
+	for _, attrib := range b {
+		key := attrib.Key()
+		if keys.has(key) {
+			switch policy {
+			case AttribCollisionSuffix:
+				for n := 2; keys.has(key); n++ {
+					key = attrib.Key() + "_" + strconv.Itoa(n)
+				}
+				merged = append(merged, &renamedAttrib{Attrib: attrib.Clone(), key: key})
+				keys.add(key)
+			case AttribCollisionError:
+				if ErrorHandler != nil {
+					ErrorHandler(fmt.Errorf("golog: attrib key %q already present for merging", key))
+				}
+			}
+			continue
+		}
+		merged = append(merged, attrib.Clone())
+		keys.add(key)
+	}
+	return merged
+}
+
+// Index returns the index of the first Attrib
+// with the passed key or -1 if there is none.
+func (a Attribs) Index(key string) int {
+	for i, attrib := range a {
+		if attrib.Key() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// Remove removes and frees all attribs with the passed keys
+// preserving the order of the remaining attribs.
+// Returns the number of removed attribs.
+func (a *Attribs) Remove(keys ...string) (removed int) {
+	if a == nil || len(*a) == 0 || len(keys) == 0 {
+		return 0
+	}
+	var remove keySet
+	for _, key := range keys {
+		remove.add(key)
+	}
+	n := 0
+	for _, attrib := range *a {
+		if remove.has(attrib.Key()) {
+			attrib.Free()
+			continue
 		}
+		(*a)[n] = attrib
+		n++
+	}
+	clear((*a)[n:])
+	removed = len(*a) - n
+	*a = (*a)[:n]
+	return removed
+}
+
+// Replace replaces and frees the first Attrib with the same key
+// as every passed attrib at its position
+// or appends the attrib if there is no Attrib with its key,
+// so later passed attribs replace earlier ones with the same key.
+// The keys are looked up in an index for large sets.
+// If a is nil the method is a no-op and nil attribs are ignored.
+func (a *Attribs) Replace(attribs ...Attrib) {
+	if a == nil {
+		return
+	}
+	var positions map[string]int
+	if len(*a)+len(attribs) > smallKeySetLen {
+		positions = make(map[string]int, len(*a)+len(attribs))
+		for i := len(*a) - 1; i >= 0; i-- {
+			positions[(*a)[i].Key()] = i
+		}
+	}
+	for _, attrib := range attribs {
+		if attrib == nil {
+			continue
+		}
+		key := attrib.Key()
+		i, ok := -1, false
+		if positions != nil {
+			if i, ok = positions[key]; !ok {
+				i = -1
+			}
+		} else {
+			i = a.Index(key)
+		}
+		if i < 0 {
+			a.Add(attrib)
+			if positions != nil {
+				positions[key] = len(*a) - 1
+			}
+			continue
+		}
+		(*a)[i].Free()
+		(*a)[i] = attrib
 	}
-	return result
 }
 
 // AppendJSON appends the attribs as a JSON object to the buffer.
@@ -203,3 +355,91 @@ func (a Attribs) AppendJSON(buf []byte) []byte {
 func (a Attribs) MarshalJSON() ([]byte, error) {
 	return a.AppendJSON(nil), nil
 }
+
+// smallKeySetLen is the number of keys up to which
+// a keySet uses a linear search instead of a map.
+// Comparing a few strings is faster than hashing them.
+const smallKeySetLen = 16
+
+// keySet is a set of attrib keys that
+// indexes its keys with a map for large sets.
+type keySet struct {
+	keys  []string
+	index map[string]struct{} // Only used for more than smallKeySetLen keys
+}
+
+func (s *keySet) has(key string) bool {
+	if len(s.keys) > smallKeySetLen {
+		_, ok := s.index[key]
+		return ok
+	}
+	for _, k := range s.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *keySet) add(key string) {
+	s.keys = append(s.keys, key)
+	switch {
+	case len(s.keys) == smallKeySetLen+1:
+		if s.index == nil {
+			s.index = make(map[string]struct{}, 2*smallKeySetLen)
+		}
+		for _, k := range s.keys {
+			s.index[k] = struct{}{}
+		}
+	case len(s.keys) > smallKeySetLen+1:
+		s.index[key] = struct{}{}
+	}
+}
+
+func (s *keySet) remove(key string) {
+	i := slices.Index(s.keys, key)
+	if i < 0 {
+		return
+	}
+	s.keys = slices.Delete(s.keys, i, i+1)
+	if len(s.keys) > smallKeySetLen {
+		if !slices.Contains(s.keys, key) {
+			delete(s.index, key)
+		}
+	} else {
+		clear(s.index)
+	}
+}
+
+// reset removes all keys but keeps the
+// allocated memory for reuse by pooled messages
+func (s *keySet) reset() {
+	clear(s.keys)
+	s.keys = s.keys[:0]
+	clear(s.index)
+}
+
+// renamedAttrib is an Attrib logged with another key
+// than the one of the wrapped Attrib.
+type renamedAttrib struct {
+	Attrib
+	key string
+}
+
+func (a *renamedAttrib) Key() string { return a.key }
+
+func (a *renamedAttrib) Clone() Attrib {
+	return &renamedAttrib{Attrib: a.Attrib.Clone(), key: a.key}
+}
+
+func (a *renamedAttrib) Log(m *Message) {
+	m.Any(a.key, a.Value())
+}
+
+func (a *renamedAttrib) AppendJSON(buf []byte) []byte {
+	return appendAnyJSON(buf, a.key, a.Value(), nil, nil)
+}
+
+func (a *renamedAttrib) String() string {
+	return fmt.Sprintf("%s as %q", a.Attrib, a.key)
+}
@@ -1,9 +1,12 @@
 package golog
 
 import (
+	"bytes"
 	"context"
+	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,3 +94,164 @@ func TestAttribFromContext(t *testing.T) {
 	require.True(t, ok, "attrib added to context")
 	require.Equal(t, attrib, NewInt("Int", 1))
 }
+
+func TestAttribs_IndexRemoveReplace(t *testing.T) {
+	attribs := Attribs{NewInt("a", 1), NewInt("b", 2), NewInt("c", 3), NewInt("b", 4)}
+	assert.Equal(t, 1, attribs.Index("b"))
+	assert.Equal(t, -1, attribs.Index("x"))
+
+	assert.Equal(t, 2, attribs.Remove("b", "x"))
+	assert.Equal(t, `{"a":1,"c":3}`, string(attribs.AppendJSON(nil)))
+
+	attribs.Replace(NewString("a", "replaced"))
+	attribs.Replace(NewInt("d", 5))
+	attribs.Replace(nil)
+	assert.Equal(t, `{"a":"replaced","c":3,"d":5}`, string(attribs.AppendJSON(nil)))
+
+	var nilAttribs Attribs
+	assert.Equal(t, 0, nilAttribs.Remove("a"))
+	nilAttribs.Replace(NewInt("a", 1))
+	assert.Equal(t, `{"a":1}`, string(nilAttribs.AppendJSON(nil)))
+}
+
+func TestAttribs_Replace_many(t *testing.T) {
+	var attribs, replacements Attribs
+	for i := range 2 * smallKeySetLen {
+		attribs = append(attribs, NewInt("a"+strconv.Itoa(i), int64(i)))
+		replacements = append(replacements, NewInt("a"+strconv.Itoa(2*i), -1))
+	}
+	// Later replacements of the same key win
+	replacements = append(replacements, NewInt("a0", -2), NewInt("a62", -2))
+	attribs.Replace(replacements...)
+
+	require.Len(t, attribs, 3*smallKeySetLen)
+	for i := range 2 * smallKeySetLen {
+		assert.Equal(t, "a"+strconv.Itoa(i), attribs[i].Key(), "replaced at position")
+	}
+	assert.Equal(t, int64(-2), attribs[0].Value())
+	assert.Equal(t, int64(-1), attribs[2].Value())
+	assert.Equal(t, int64(1), attribs[1].Value())
+	assert.Equal(t, "a32", attribs[2*smallKeySetLen].Key(), "appended")
+	assert.Equal(t, "a62", attribs[3*smallKeySetLen-1].Key())
+	assert.Equal(t, int64(-2), attribs[3*smallKeySetLen-1].Value())
+}
+
+func TestAttribs_CloneAndMerge(t *testing.T) {
+	a := Attribs{NewString("a", "a"), NewString("b", "a")}
+	b := Attribs{NewString("b", "b"), NewString("c", "b"), NewString("c", "b2")}
+	for _, tt := range []struct {
+		policy AttribCollisionPolicy
+		want   string
+	}{
+		{AttribCollisionFirstWins, `{"a":"a","b":"a","c":"b"}`},
+		{AttribCollisionLastWins, `{"a":"a","b":"b","c":"b2"}`},
+		{AttribCollisionSuffix, `{"a":"a","b":"a","b_2":"b","c":"b","c_2":"b2"}`},
+	} {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			merged := a.CloneAndMerge(b, tt.policy)
+			assert.Equal(t, tt.want, string(merged.AppendJSON(nil)))
+			assert.NotSame(t, a[0], merged[0], "attribs are cloned")
+		})
+	}
+	assert.Equal(t, `{"a":"a","b":"a"}`, string(a.AppendJSON(nil)), "a not modified")
+	assert.Equal(t, `{"b":"b","c":"b","c":"b2"}`, string(b.AppendJSON(nil)), "b not modified")
+	assert.Nil(t, Attribs(nil).CloneAndMerge(nil, AttribCollisionFirstWins))
+}
+
+type testCustomAttrib struct{ Attrib }
+
+func (a testCustomAttrib) Clone() Attrib { return testCustomAttrib{a.Attrib.Clone()} }
+
+func TestAttribs_CloneAndMerge_customAttrib(t *testing.T) {
+	a := Attribs{NewString("a", "a")}
+	b := Attribs{testCustomAttrib{NewString("b", "b")}, testCustomAttrib{NewString("a", "b")}}
+	for _, policy := range []AttribCollisionPolicy{AttribCollisionFirstWins, AttribCollisionLastWins, AttribCollisionSuffix} {
+		merged := a.CloneAndMerge(b, policy)
+		assert.IsType(t, testCustomAttrib{}, merged.Get("b"), "custom attrib cloned, not rebuilt")
+	}
+
+	merged := a.CloneAndMerge(b, AttribCollisionSuffix)
+	assert.Equal(t, "a_2", merged[2].Key())
+	assert.Equal(t, `{"a":"a","b":"b","a_2":"b"}`, string(merged.AppendJSON(nil)))
+}
+
+func TestAttribs_CloneAndMerge_Large(t *testing.T) {
+	var a, b Attribs
+	for i := range 3 * smallKeySetLen {
+		a = append(a, NewInt("key"+strconv.Itoa(i), 1))
+		b = append(b, NewInt("key"+strconv.Itoa(2*i), 2))
+	}
+	merged := a.CloneAndMerge(b, AttribCollisionFirstWins)
+	require.Len(t, merged, 3*smallKeySetLen+3*smallKeySetLen/2)
+	for i, attrib := range merged {
+		if i < 3*smallKeySetLen {
+			assert.Equal(t, "key"+strconv.Itoa(i), attrib.Key())
+			assert.Equal(t, int64(1), attrib.Value())
+		} else {
+			// Even keys of b not in a
+			assert.Equal(t, "key"+strconv.Itoa(2*i-3*smallKeySetLen), attrib.Key())
+			assert.Equal(t, int64(2), attrib.Value())
+		}
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	var s keySet
+	for i := range 2 * smallKeySetLen {
+		key := strconv.Itoa(i)
+		require.False(t, s.has(key))
+		s.add(key)
+		require.True(t, s.has(key))
+	}
+	for i := range 2 * smallKeySetLen {
+		key := strconv.Itoa(i)
+		s.remove(key)
+		require.False(t, s.has(key), key)
+		if i+1 < 2*smallKeySetLen {
+			require.True(t, s.has(strconv.Itoa(i+1)))
+		}
+	}
+	s.add("a")
+	s.reset()
+	assert.False(t, s.has("a"))
+}
+
+func TestLogger_WithReplacedAttribs_WithoutAttribs(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(
+		NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})),
+		NewString("a", "a"), NewString("b", "b"), NewString("c", "c"),
+	)
+
+	log.WithReplacedAttribs(NewString("b", "replaced"), NewString("d", "d")).Info("").Log()
+	assert.Equal(t, `{"a":"a","b":"replaced","c":"c","d":"d"}`+"\n", buf.String())
+
+	buf.Reset()
+	log.WithoutAttribs("a", "c").Info("").Log()
+	assert.Equal(t, `{"b":"b"}`+"\n", buf.String())
+
+	buf.Reset()
+	log.Info("").Log()
+	assert.Equal(t, `{"a":"a","b":"b","c":"c"}`+"\n", buf.String(), "original logger not modified")
+}
+
+func TestMessage_ManyAttribKeys(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{}))).
+		WithAttribKeyCollision(AttribCollisionFirstWins)
+
+	msg := log.Info("")
+	for i := range 3 * smallKeySetLen {
+		msg.Int("key"+strconv.Itoa(i%(2*smallKeySetLen)), i)
+	}
+	msg.Log()
+
+	want := "{"
+	for i := range 2 * smallKeySetLen {
+		if i > 0 {
+			want += ","
+		}
+		want += `"key` + strconv.Itoa(i) + `":` + strconv.Itoa(i)
+	}
+	assert.Equal(t, want+"}\n", buf.String())
+}
@@ -1,160 +1,246 @@
 package golog
 
-// import (
-// 	"fmt"
-// 	"time"
-// )
-
-// var (
-// 	_ Writer       = new(attribsRecorder)
-// 	_ fmt.Stringer = new(attribsRecorder)
-// )
-
-// // attribsRecorder implements the Writer interface
-// // and records all logged attributes that can be retrieved
-// // with the Attribs() method.
-// type attribsRecorder struct {
-// 	currentKey   string
-// 	writingSlice bool
-// 	currentSlice Attrib
-
-// 	recorded Attribs
-// }
-
-// // NewAttribsRecorder returns a Writer that records all logged attributes
-// // instead of formatting and printing them somewhere.
-// // The recorded values can be retrieved with the Attrs() method.
-// func NewAttribsRecorder() *attribsRecorder { return new(attribsRecorder) }
-
-// // Attribs returns the recorded values
-// func (r *attribsRecorder) Attribs() Attribs {
-// 	return r.recorded
-// }
-
-// func (r *attribsRecorder) BeginMessage(logger *Logger, t time.Time, level Level, text string) Writer {
-// 	panic("BeginMessage not supported by golog.attribsRecorder")
-// }
-
-// func (r *attribsRecorder) CommitMessage() {
-// 	panic("calling golog.Message.Log() after golog.Logger.With() is not valid, call golog.Message.SubLogger() instead")
-// }
-
-// func (r *attribsRecorder) FlushUnderlying() {}
-
-// func (r *attribsRecorder) String() string {
-// 	return fmt.Sprintf("golog.attribsRecorder with %d recored attribs", r.recorded)
-// }
-
-// func (r *attribsRecorder) WriteKey(key string) {
-// 	r.currentKey = key
-// }
-
-// func (r *attribsRecorder) WriteSliceKey(key string) {
-// 	r.currentKey = key
-// 	r.writingSlice = true
-// }
-
-// func (r *attribsRecorder) WriteSliceEnd() {
-// 	r.recorded = append(r.recorded, r.currentSlice)
-// 	r.currentSlice = nil
-// 	r.writingSlice = false
-// }
-
-// func (r *attribsRecorder) WriteNil() {
-// 	r.recorded = append(r.recorded, Nil{r.currentKey})
-// }
-
-// func (r *attribsRecorder) WriteBool(val bool) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*Bools); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &Bools{r.currentKey, []bool{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, Bool{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteInt(val int64) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*Ints); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &Ints{r.currentKey, []int64{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, Int{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteUint(val uint64) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*Uints); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &Uints{r.currentKey, []uint64{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, Uint{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteFloat(val float64) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*Floats); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &Floats{r.currentKey, []float64{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, Float{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteString(val string) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*Strings); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &Strings{r.currentKey, []string{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, String{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteError(val error) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*Errors); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &Errors{r.currentKey, []error{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, Error{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteUUID(val [16]byte) {
-// 	if r.writingSlice {
-// 		// Need pointer type to be able to modify slice.Vals
-// 		if slice, ok := r.currentSlice.(*UUIDs); ok {
-// 			slice.Vals = append(slice.Vals, val)
-// 		} else {
-// 			r.currentSlice = &UUIDs{r.currentKey, [][16]byte{val}}
-// 		}
-// 	} else {
-// 		r.recorded = append(r.recorded, UUID{r.currentKey, val})
-// 	}
-// }
-
-// func (r *attribsRecorder) WriteJSON(val []byte) {
-// 	r.recorded = append(r.recorded, JSON{r.currentKey, val})
-// }
+import (
+	"reflect"
+	"time"
+)
+
+// attribsRecorder implements the value writing methods
+// of the Writer interface by recording all written
+// keys and values as Attribs.
+//
+// It is embedded by Writer implementations like CallbackWriter
+// that need access to the typed attributes of a message
+// instead of formatting them.
+type attribsRecorder struct {
+	attribs     Attribs
+	key         string
+	isSlice     bool
+	sliceAttrib SliceAttrib
+}
+
+func (r *attribsRecorder) WriteKey(key string) {
+	r.key = key
+}
+
+func (r *attribsRecorder) WriteSliceKey(key string) {
+	r.key = key
+	r.isSlice = true
+}
+
+func (r *attribsRecorder) WriteSliceEnd() {
+	r.attribs = append(r.attribs, r.sliceAttrib)
+	r.sliceAttrib = nil
+	r.isSlice = false
+}
+
+func (r *attribsRecorder) WriteNil() {
+	r.attribs = append(r.attribs, NewNil(r.key))
+}
+
+func (r *attribsRecorder) WriteBool(val bool) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Bools)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewBools(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewBool(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteInt(val int64) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Ints)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewInts(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewInt(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteUint(val uint64) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Uints)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewUints(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewUint(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteFloat(val float64) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Floats)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewFloats(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewFloat(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteString(val string) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Strings)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewStrings(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewString(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteError(val error) {
+	if val == nil {
+		r.WriteNil()
+		return
+	}
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Errors)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewErrors(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewError(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteTime(val time.Time) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Times)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewTimes(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewTime(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteDuration(val time.Duration) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Durations)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewDurations(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewDuration(r.key, val))
+	}
+}
+
+// recordAnyDuration records a time.Duration logged with Message.Any
+// as Any attrib so that the writers the recorded attribs
+// are written to later decide how to write it.
+// Used by writers implementing anyDurationWriter.
+func (r *attribsRecorder) recordAnyDuration(val time.Duration) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*Anys)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewAnys(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewAny(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteUUID(val [16]byte) {
+	if r.isSlice {
+		a, _ := r.sliceAttrib.(*UUIDs)
+		if a == nil && r.sliceAttrib != nil {
+			r.appendMixedSliceValue(val)
+			return
+		}
+		if a == nil {
+			a = NewUUIDs(r.key, nil)
+			r.sliceAttrib = a
+		}
+		a.vals = append(a.vals, val)
+	} else {
+		r.attribs = append(r.attribs, NewUUID(r.key, val))
+	}
+}
+
+func (r *attribsRecorder) WriteJSON(val []byte) {
+	if len(val) == 0 {
+		val = []byte("null")
+	}
+	r.attribs = append(r.attribs, NewJSON(r.key, val))
+}
+
+func (r *attribsRecorder) WriteSource(source Source) {
+	r.attribs = append(r.attribs, NewAny(r.key, source))
+}
+
+// appendMixedSliceValue appends val to the current slice attrib
+// that was started with values of another type
+// by converting it to Anys.
+func (r *attribsRecorder) appendMixedSliceValue(val any) {
+	a, ok := r.sliceAttrib.(*Anys)
+	if !ok {
+		vals := reflect.ValueOf(r.sliceAttrib.Value())
+		anys := make([]any, vals.Len(), vals.Len()+1)
+		for i := range anys {
+			anys[i] = vals.Index(i).Interface()
+		}
+		r.sliceAttrib.Free()
+		a = NewAnys(r.key, anys)
+		r.sliceAttrib = a
+	}
+	a.vals = append(a.vals, val)
+}
@@ -0,0 +1,71 @@
+package benchmarks
+
+import (
+	"io"
+	"testing"
+
+	"github.com/domonda/golog"
+)
+
+type testStruct struct {
+	UserID    string  `json:"user_id"`
+	RequestID string  `json:"request_id"`
+	Count     int     `json:"count"`
+	Ratio     float64 `json:"ratio"`
+	Active    bool    `json:"active"`
+	Comment   string  `json:"comment,omitempty"`
+	Password  string  `json:"password,redact"`
+	Internal  string
+}
+
+var testStructValue = &testStruct{
+	UserID:    testUserIDValue,
+	RequestID: testRequestID,
+	Count:     testIntValue,
+	Ratio:     testFloatValue,
+	Active:    testBoolValue,
+	Password:  "secret",
+	Internal:  "not logged",
+}
+
+// BenchmarkStructFields compares logging a struct with StructFields
+// using the cached reflection plan to hand-written typed calls
+func BenchmarkStructFields(b *testing.B) {
+	logger := golog.NewLogger(golog.NewConfig(
+		&golog.DefaultLevels,
+		golog.AllLevelsActive,
+		golog.NewJSONWriterConfig(io.Discard, nil),
+	))
+
+	b.Run("StructFields", func(b *testing.B) {
+		cooldown()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for b.Loop() {
+			logger.Info(testMessage).StructFields(testStructValue).Log()
+		}
+	})
+
+	b.Run("typed", func(b *testing.B) {
+		cooldown()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+
+		for b.Loop() {
+			s := testStructValue
+			m := logger.Info(testMessage).
+				Str("user_id", s.UserID).
+				Str("request_id", s.RequestID).
+				Int("count", s.Count).
+				Float("ratio", s.Ratio).
+				Bool("active", s.Active)
+			if s.Comment != "" {
+				m = m.Str("comment", s.Comment)
+			}
+			m.Str("password", "***REDACTED***").Log()
+		}
+	})
+}
@@ -32,6 +32,12 @@ func NewCallbackWriterConfig(callback MessageCallback, filters ...LevelFilter) *
 	}
 }
 
+// IsActive implements the LevelDecider interface
+// by returning if the filter of the config passes the level.
+func (c *CallbackWriterConfig) IsActive(ctx context.Context, level Level) bool {
+	return c.filter.IsActive(ctx, level)
+}
+
 func (c *CallbackWriterConfig) WriterForNewMessage(ctx context.Context, level Level) Writer {
 	if c.filter.IsInactive(ctx, level) {
 		return nil
@@ -46,16 +52,14 @@ func (c *CallbackWriterConfig) FlushUnderlying() {}
 ///////////////////////////////////////////////////////////////////////////////
 
 type CallbackWriter struct {
-	config      *CallbackWriterConfig
-	levels      *Levels
-	timestamp   time.Time
-	level       Level
-	prefix      string
-	text        string
-	attribs     Attribs
-	key         string
-	isSlice     bool
-	sliceAttrib SliceAttrib
+	config    *CallbackWriterConfig
+	levels    *Levels
+	timestamp time.Time
+	level     Level
+	prefix    string
+	text      string
+
+	attribsRecorder
 }
 
 func (w *CallbackWriter) BeginMessage(config Config, timestamp time.Time, level Level, prefix, text string) {
@@ -104,137 +108,3 @@ func (w *CallbackWriter) String() string {
 	}
 	return b.String()
 }
-
-func (w *CallbackWriter) WriteKey(key string) {
-	w.key = key
-}
-
-func (w *CallbackWriter) WriteSliceKey(key string) {
-	w.key = key
-	w.isSlice = true
-}
-
-func (w *CallbackWriter) WriteSliceEnd() {
-	w.attribs = append(w.attribs, w.sliceAttrib)
-	w.sliceAttrib = nil
-	w.isSlice = false
-}
-
-func (w *CallbackWriter) WriteNil() {
-	w.attribs = append(w.attribs, NewNil(w.key))
-}
-
-func (w *CallbackWriter) WriteBool(val bool) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Bools)
-		if a == nil {
-			a = NewBools(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewBool(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteInt(val int64) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Ints)
-		if a == nil {
-			a = NewInts(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewInt(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteUint(val uint64) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Uints)
-		if a == nil {
-			a = NewUints(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewUint(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteFloat(val float64) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Floats)
-		if a == nil {
-			a = NewFloats(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewFloat(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteString(val string) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Strings)
-		if a == nil {
-			a = NewStrings(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewString(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteError(val error) {
-	if val == nil {
-		w.WriteNil()
-		return
-	}
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Errors)
-		if a == nil {
-			a = NewErrors(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewError(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteTime(val time.Time) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*Times)
-		if a == nil {
-			a = NewTimes(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewTime(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteUUID(val [16]byte) {
-	if w.isSlice {
-		a, _ := w.sliceAttrib.(*UUIDs)
-		if a == nil {
-			a = NewUUIDs(w.key, nil)
-			w.sliceAttrib = a
-		}
-		a.vals = append(a.vals, val)
-	} else {
-		w.attribs = append(w.attribs, NewUUID(w.key, val))
-	}
-}
-
-func (w *CallbackWriter) WriteJSON(val []byte) {
-	if len(val) == 0 {
-		val = []byte("null")
-	}
-	w.attribs = append(w.attribs, NewJSON(w.key, val))
-}
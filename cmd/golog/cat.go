@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/muesli/termenv"
+
+	"github.com/domonda/golog"
+	"github.com/domonda/golog/log"
+)
+
+// runCat renders JSON log lines as text
+func runCat(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var (
+		flags     = flag.NewFlagSet("golog cat", flag.ContinueOnError)
+		filter    = newRecordFilter()
+		options   inputOptions
+		color     string
+		utc       bool
+		prefixSep string
+	)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: golog [cat] [flags] [files...]")
+		fmt.Fprintln(stderr, "Renders JSON log lines from stdin or files as colorized text.")
+		flags.PrintDefaults()
+	}
+	filter.addFlags(flags, &golog.DefaultLevels)
+	flags.BoolVar(&options.follow, "follow", false, "wait for lines appended to the last file like tail -F")
+	flags.BoolVar(&options.rotated, "rotated", false, "read the rotated files of every file first")
+	flags.StringVar(&color, "color", "auto", "colorize output: auto, always, or never")
+	flags.BoolVar(&utc, "utc", false, "format timestamps in UTC")
+	flags.StringVar(&prefixSep, "prefix-sep", ": ", "separator of a prefix in the message text, empty to disable")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var colorizer golog.Colorizer
+	switch color {
+	case "auto":
+		colorizer = log.NewStyledColorizer()
+	case "always":
+		// Use the terminal's profile but at least ANSI colors
+		// even if stdout is not a terminal or NO_COLOR is set
+		profile := termenv.ColorProfile()
+		if profile == termenv.Ascii {
+			profile = termenv.ANSI
+		}
+		colorizer = log.NewStyledColorizerWithProfile(profile)
+	case "never":
+		colorizer = golog.NoColorizer
+	default:
+		return fmt.Errorf("invalid -color %q, expected auto, always, or never", color)
+	}
+	format := golog.NewDefaultFormat()
+	if utc {
+		format.Location = time.UTC
+	}
+	config := golog.NewConfig(&golog.DefaultLevels, golog.AllLevelsActive, golog.NewTextWriterConfig(stdout, format, colorizer))
+	parser := recordParser(format)
+	parser.PrefixSep = prefixSep
+
+	return readLines(ctx, flags.Args(), stdin, options, func(line []byte) error {
+		record, err := parser.ParseLine(line)
+		if err != nil {
+			// Pass through lines that are not JSON if not filtering
+			if filter.isEmpty() {
+				_, err = fmt.Fprintf(stdout, "%s\n", line)
+				return err
+			}
+			return nil
+		}
+		if filter.match(record) {
+			record.Log(ctx, config)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLines = `{"time":"2024-01-02 03:04:05.000","level":"INFO","message":"db: Connected","host":"localhost"}
+not JSON
+{"time":"2024-01-02 03:04:06.000","level":"WARN","message":"db: Slow query","took_ms":1500}
+{"time":"2024-01-02 03:04:07.000","level":"ERROR","message":"http: Request failed","status":500,"requestID":"994d5800-afca-401f-9c2f-d9e3e106e9ef"}
+`
+
+func runTest(t *testing.T, stdin string, args ...string) string {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(t.Context(), args, strings.NewReader(stdin), &stdout, &stderr)
+	require.NoError(t, err, stderr.String())
+	return stdout.String()
+}
+
+// untimedLines returns the text output of info messages without timestamp
+func untimedLines(texts ...string) string {
+	var b strings.Builder
+	for _, text := range texts {
+		b.WriteString("0001-01-01 00:00:00.000 |INFO | " + text + "\n")
+	}
+	return b.String()
+}
+
+func TestCat(t *testing.T) {
+	assert.Equal(t,
+		"2024-01-02 03:04:05.000 |INFO | db: Connected host=\"localhost\"\n"+
+			"not JSON\n"+
+			"2024-01-02 03:04:06.000 |WARN | db: Slow query took_ms=1500\n"+
+			"2024-01-02 03:04:07.000 |ERROR| http: Request failed status=500 requestID=994d5800-afca-401f-9c2f-d9e3e106e9ef\n",
+		runTest(t, testLines, "-color", "never"),
+	)
+}
+
+func TestCat_colorAlways(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("CLICOLOR_FORCE", "")
+
+	output := runTest(t, testLines, "-color", "always")
+	assert.Contains(t, output, "\x1b[", "colorized without a terminal and with NO_COLOR")
+	assert.Contains(t, output, "Connected")
+	assert.Empty(t, os.Getenv("CLICOLOR_FORCE"), "environment not modified")
+
+	assert.NotContains(t, runTest(t, testLines, "-color", "auto"), "\x1b[", "NO_COLOR respected by auto")
+}
+
+func TestCat_filter(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{args: []string{"-level", "warn"}, want: []string{"Slow query", "Request failed"}},
+		{args: []string{"-prefix", "d*"}, want: []string{"Connected", "Slow query"}},
+		{args: []string{"-since", "2024-01-02 03:04:06", "-until", "2024-01-02 03:04:07"}, want: []string{"Slow query"}},
+		{args: []string{"-attr", "status=500"}, want: []string{"Request failed"}},
+		{args: []string{"-attr", "took_ms"}, want: []string{"Slow query"}},
+		{args: []string{"-attr", "host=other"}, want: nil},
+		{args: []string{"-grep", "^(Connected|Request)"}, want: []string{"Connected", "Request failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output := runTest(t, testLines, append([]string{"-color", "never"}, tt.args...)...)
+			lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+			if output == "" {
+				lines = nil
+			}
+			require.Len(t, lines, len(tt.want), output)
+			for i, want := range tt.want {
+				assert.Contains(t, lines[i], want)
+			}
+		})
+	}
+}
+
+func TestCat_rotated(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writeFile := func(name, content string, modTime time.Time) {
+		t.Helper()
+		filePath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+		require.NoError(t, os.Chtimes(filePath, modTime, modTime))
+	}
+	var gz bytes.Buffer
+	gzWriter := gzip.NewWriter(&gz)
+	_, err := gzWriter.Write([]byte(`{"message":"First"}` + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gzWriter.Close())
+
+	now := time.Now()
+	writeFile("app.log", `{"message":"Third"}`+"\n", now)
+	writeFile("app.log.2024-01-02_03:04:06", `{"message":"Second"}`+"\n", now.Add(-time.Hour))
+	writeFile("app.log.2024-01-02_03:04:05.gz", gz.String(), now.Add(-2*time.Hour))
+	writeFile("other.log", `{"message":"Other"}`+"\n", now)
+
+	assert.Equal(t, untimedLines("Third"), runTest(t, "", "-color", "never", path))
+	assert.Equal(t, untimedLines("First", "Second", "Third"), runTest(t, "", "-color", "never", "-rotated", path))
+}
+
+func TestCat_follow(t *testing.T) {
+	followInterval = time.Millisecond
+	path := filepath.Join(t.TempDir(), "app.log")
+	require.NoError(t, os.WriteFile(path, []byte(`{"message":"First"}`+"\n"+`{"message":"Sec`), 0o600))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	var stdout, stderr bytes.Buffer
+	done := make(chan error)
+	go func() {
+		done <- run(ctx, []string{"-color", "never", "-follow", path}, nil, &stdout, &stderr)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = file.WriteString(`ond"}` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// Rotate and write a new file
+	time.Sleep(20 * time.Millisecond)
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, os.WriteFile(path, []byte(`{"message":"Third"}`+"\n"), 0o600))
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, untimedLines("First", "Second", "Third"), stdout.String())
+}
+
+func Test_parseTimeFlag(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	got, err := parseTimeFlag("1h30m", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-90*time.Minute), got)
+
+	got, err = parseTimeFlag("2024-01-02T01:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC), got)
+
+	_, err = parseTimeFlag("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/domonda/golog"
+)
+
+// expr is a parsed filter expression evaluated for a record.
+//
+// Grammar:
+//
+//	expr       = and { ("or" | "||") and }
+//	and        = not { ("and" | "&&") not }
+//	not        = ("not" | "!") not | primary
+//	primary    = "(" expr ")" | field [ op value ]
+//	op         = "=" | "==" | "!=" | "<" | "<=" | ">" | ">=" | "~" | "!~"
+//
+// A field without operator is true if the record has the field.
+// The fields "level", "prefix", "message" (or "msg"), and "time"
+// refer to the parsed parts of the record, all other fields to attribs.
+// Values are bare words or double quoted strings.
+//
+// Levels are compared by their numeric value, so "level >= warn"
+// matches warnings, errors, and fatal messages.
+// Times are compared with timestamps or durations ago like "time > 15m".
+// Numbers and durations like "1.5s" are compared numerically,
+// all other values as strings. The operator "~" matches a regular expression.
+type expr interface {
+	eval(r *golog.JSONRecord) bool
+}
+
+type orExpr struct{ left, right expr }
+
+func (e orExpr) eval(r *golog.JSONRecord) bool { return e.left.eval(r) || e.right.eval(r) }
+
+type andExpr struct{ left, right expr }
+
+func (e andExpr) eval(r *golog.JSONRecord) bool { return e.left.eval(r) && e.right.eval(r) }
+
+type notExpr struct{ expr expr }
+
+func (e notExpr) eval(r *golog.JSONRecord) bool { return !e.expr.eval(r) }
+
+type existsExpr struct{ field string }
+
+func (e existsExpr) eval(r *golog.JSONRecord) bool {
+	_, ok := fieldOf(r, e.field)
+	return ok
+}
+
+// compareExpr compares a field with a value
+// that is converted to the kind of the field once when parsed.
+type compareExpr struct {
+	field string
+	op    string
+	value string
+	regex *regexp.Regexp
+
+	level      golog.Level
+	time       time.Time
+	number     float64
+	isNumber   bool
+	duration   float64
+	isDuration bool
+}
+
+func (e *compareExpr) eval(r *golog.JSONRecord) bool {
+	f, ok := fieldOf(r, e.field)
+	if !ok {
+		return e.op == "!=" || e.op == "!~"
+	}
+	switch e.op {
+	case "~":
+		return e.regex.MatchString(f.str)
+	case "!~":
+		return !e.regex.MatchString(f.str)
+	}
+	switch {
+	case f.kind == levelField && e.level != golog.LevelInvalid:
+		return compareOrdered(e.op, f.num, float64(e.level))
+	case f.kind == timeField && !e.time.IsZero():
+		return compareOrdered(e.op, f.num, float64(e.time.UnixNano()))
+	case f.kind == durationField && e.isDuration:
+		return compareOrdered(e.op, f.num, e.duration)
+	case f.kind == numberField && e.isNumber:
+		return compareOrdered(e.op, f.num, e.number)
+	}
+	return compareOrdered(e.op, f.str, e.value)
+}
+
+func compareOrdered[T float64 | string](op string, a, b T) bool {
+	switch op {
+	case "=", "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+type fieldKind int
+
+const (
+	stringField fieldKind = iota
+	numberField
+	durationField
+	levelField
+	timeField
+)
+
+// field is the value of a record field as string
+// and if its kind is not stringField also as number,
+// with durations as nanoseconds and times as Unix nanoseconds.
+type field struct {
+	kind fieldKind
+	str  string
+	num  float64
+}
+
+// fieldOf returns the named field of a record.
+func fieldOf(r *golog.JSONRecord, name string) (field, bool) {
+	switch name {
+	case "level":
+		return field{kind: levelField, str: golog.DefaultLevels.Name(r.Level), num: float64(r.Level)}, true
+	case "prefix":
+		return field{str: r.Prefix}, r.Prefix != ""
+	case "message", "msg":
+		return field{str: r.Text}, true
+	case "time":
+		if r.Timestamp.IsZero() {
+			return field{}, false
+		}
+		return field{kind: timeField, str: r.Timestamp.Format(time.RFC3339Nano), num: float64(r.Timestamp.UnixNano())}, true
+	}
+	attrib := r.Attribs.Get(name)
+	if attrib == nil {
+		return field{}, false
+	}
+	f := field{str: attrib.ValueString()}
+	switch v := attrib.Value().(type) {
+	case int64:
+		f.kind, f.num = numberField, float64(v)
+	case uint64:
+		f.kind, f.num = numberField, float64(v)
+	case float64:
+		f.kind, f.num = numberField, v
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			f.kind, f.num = durationField, float64(d)
+		}
+	}
+	return f, true
+}
+
+// parseExpr parses a filter expression, see expr.
+// An empty string returns a nil expr.
+func parseExpr(s string) (expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	p := &exprParser{tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in expression", p.tokens[p.pos].text)
+	}
+	return e, nil
+}
+
+type tokenType int
+
+const (
+	wordToken tokenType = iota
+	stringToken
+	opToken
+)
+
+type token struct {
+	typ  tokenType
+	text string
+}
+
+// isWordChar returns true for characters of bare words
+func isWordChar(r rune) bool {
+	return !unicode.IsSpace(r) && !strings.ContainsRune(`()=!<>~"&|`, r)
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(s) && s[end] != '"'; end++ {
+				if s[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(s) {
+				return nil, errors.New("unterminated string in expression")
+			}
+			str, err := strconv.Unquote(s[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s in expression: %w", s[i:end+1], err)
+			}
+			tokens = append(tokens, token{stringToken, str})
+			i = end + 1
+		case strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="), strings.HasPrefix(s[i:], "!~"),
+			strings.HasPrefix(s[i:], "<="), strings.HasPrefix(s[i:], ">="),
+			strings.HasPrefix(s[i:], "&&"), strings.HasPrefix(s[i:], "||"):
+			tokens = append(tokens, token{opToken, s[i : i+2]})
+			i += 2
+		case strings.ContainsRune("()=!<>~", rune(c)):
+			tokens = append(tokens, token{opToken, s[i : i+1]})
+			i++
+		default:
+			end := i
+			for end < len(s) {
+				r, size := utf8.DecodeRuneInString(s[end:])
+				if !isWordChar(r) {
+					break
+				}
+				end += size
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected %q in expression", s[i:i+1])
+			}
+			tokens = append(tokens, token{wordToken, s[i:end]})
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// accept consumes the next token if it is
+// an operator or keyword out of texts.
+func (p *exprParser) accept(texts ...string) bool {
+	t, ok := p.peek()
+	if !ok || t.typ == stringToken {
+		return false
+	}
+	for _, text := range texts {
+		if strings.EqualFold(t.text, text) {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *exprParser) parseOr() (expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or", "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and", "&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (expr, error) {
+	if p.accept("not", "!") {
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{e}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (expr, error) {
+	if p.accept("(") {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.accept(")") {
+			return nil, errors.New("missing ) in expression")
+		}
+		return e, nil
+	}
+	t, ok := p.peek()
+	if !ok {
+		return nil, errors.New("unexpected end of expression")
+	}
+	if t.typ == opToken {
+		return nil, fmt.Errorf("unexpected %q in expression", t.text)
+	}
+	p.pos++
+	name := t.text
+
+	op, ok := p.peek()
+	if !ok || op.typ != opToken || op.text == "(" || op.text == ")" || op.text == "!" || op.text == "&&" || op.text == "||" {
+		return existsExpr{field: name}, nil
+	}
+	p.pos++
+	value, ok := p.peek()
+	if !ok || value.typ == opToken {
+		return nil, fmt.Errorf("missing value after %s %s in expression", name, op.text)
+	}
+	p.pos++
+	return newCompareExpr(name, op.text, value.text)
+}
+
+func newCompareExpr(field, op, value string) (*compareExpr, error) {
+	e := &compareExpr{field: field, op: op, value: value, level: golog.LevelInvalid}
+	if op == "~" || op == "!~" {
+		regex, err := regexp.Compile(value)
+		if err != nil {
+			return nil, err
+		}
+		e.regex = regex
+		return e, nil
+	}
+	switch field {
+	case "level":
+		e.level = golog.DefaultLevels.LevelOfName(strings.ToUpper(value))
+		if e.level == golog.LevelInvalid {
+			return nil, fmt.Errorf("invalid level %q in expression", value)
+		}
+	case "time":
+		t, err := parseTimeFlag(value, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		e.time = t
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		e.duration, e.isDuration = float64(d), true
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(n) {
+		e.number, e.isNumber = n, true
+	}
+	return e, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/domonda/golog"
+)
+
+// recordFilter selects the records that are output.
+// The zero value matches all records.
+type recordFilter struct {
+	minLevel golog.Level
+	prefix   string
+	since    time.Time
+	until    time.Time
+	attribs  []attribMatch
+	grep     *regexp.Regexp
+}
+
+// attribMatch matches records having an attrib with key
+// and if hasValue is true also the value.
+type attribMatch struct {
+	key      string
+	value    string
+	hasValue bool
+}
+
+func newRecordFilter() *recordFilter {
+	return &recordFilter{minLevel: golog.LevelInvalid}
+}
+
+// addFlags registers the filter flags at flags
+// parsing level names and numbers with levels.
+func (f *recordFilter) addFlags(flags *flag.FlagSet, levels *golog.Levels) {
+	flags.Func("level", "minimum level name or number of records", func(s string) error {
+		level := levels.LevelOfName(strings.ToUpper(s))
+		if level == golog.LevelInvalid {
+			return fmt.Errorf("invalid level %q", s)
+		}
+		f.minLevel = level
+		return nil
+	})
+	flags.StringVar(&f.prefix, "prefix", "", "only records with a prefix matching the `pattern` (see path.Match)")
+	flags.Func("since", "only records at or after a timestamp or a duration ago like 15m", func(s string) (err error) {
+		f.since, err = parseTimeFlag(s, time.Now())
+		return err
+	})
+	flags.Func("until", "only records before a timestamp or a duration ago like 15m", func(s string) (err error) {
+		f.until, err = parseTimeFlag(s, time.Now())
+		return err
+	})
+	flags.Func("attr", "only records with an attrib `key[=value]`, can be repeated", func(s string) error {
+		key, value, hasValue := strings.Cut(s, "=")
+		if key == "" {
+			return fmt.Errorf("empty attrib key in %q", s)
+		}
+		f.attribs = append(f.attribs, attribMatch{key: key, value: value, hasValue: hasValue})
+		return nil
+	})
+	flags.Func("grep", "only records with a message text matching the regular `expression`", func(s string) (err error) {
+		f.grep, err = regexp.Compile(s)
+		return err
+	})
+}
+
+// parseTimeFlag parses s as duration before now
+// or as timestamp with golog.ParseTimestamp.
+func parseTimeFlag(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := golog.ParseTimestamp(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected timestamp or duration", s)
+	}
+	return t.Time, nil
+}
+
+// isEmpty returns true if the filter matches all records.
+func (f *recordFilter) isEmpty() bool {
+	return f.minLevel == golog.LevelInvalid &&
+		f.prefix == "" &&
+		f.since.IsZero() &&
+		f.until.IsZero() &&
+		len(f.attribs) == 0 &&
+		f.grep == nil
+}
+
+// match returns true if the record passes all conditions of the filter.
+// Records without timestamp don't match a time range.
+func (f *recordFilter) match(r *golog.JSONRecord) bool {
+	if f.minLevel != golog.LevelInvalid && r.Level < f.minLevel {
+		return false
+	}
+	if f.prefix != "" {
+		if ok, _ := path.Match(f.prefix, r.Prefix); !ok {
+			return false
+		}
+	}
+	if !f.since.IsZero() && (r.Timestamp.IsZero() || r.Timestamp.Before(f.since)) {
+		return false
+	}
+	if !f.until.IsZero() && (r.Timestamp.IsZero() || !r.Timestamp.Before(f.until)) {
+		return false
+	}
+	for _, m := range f.attribs {
+		attrib := r.Attribs.Get(m.key)
+		if attrib == nil || m.hasValue && attrib.ValueString() != m.value {
+			return false
+		}
+	}
+	if f.grep != nil && !f.grep.MatchString(r.Text) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/domonda/golog"
+)
+
+// followInterval is the interval for polling
+// a followed file for new data.
+var followInterval = 250 * time.Millisecond
+
+// inputOptions configures how the files of a command are read.
+type inputOptions struct {
+	rotated bool
+	follow  bool
+}
+
+// inputFiles returns the files to read in order.
+// If rotated is true, then the rotated files of every path
+// are inserted in chronological order before the path.
+func inputFiles(paths []string, rotated bool) ([]string, error) {
+	if !rotated {
+		return paths, nil
+	}
+	var files []string
+	for _, path := range paths {
+		rotatedFiles, err := rotatedFilesOf(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, rotatedFiles...)
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// rotatedFilesOf returns the files in the directory of path
+// with names starting with the name of path followed by a dot
+// like the files renamed by logfile.RotatingWriter,
+// sorted by modification time and name.
+func rotatedFilesOf(path string) ([]string, error) {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type rotatedFile struct {
+		path    string
+		modTime time.Time
+	}
+	var rotated []rotatedFile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), name+".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		rotated = append(rotated, rotatedFile{
+			path:    filepath.Join(filepath.Dir(path), entry.Name()),
+			modTime: info.ModTime(),
+		})
+	}
+	slices.SortFunc(rotated, func(a, b rotatedFile) int {
+		if c := a.modTime.Compare(b.modTime); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.path, b.path)
+	})
+	files := make([]string, len(rotated))
+	for i, r := range rotated {
+		files[i] = r.path
+	}
+	return files, nil
+}
+
+// openFile opens a file for reading and decompresses it
+// if the name ends with ".gz".
+// If follow is true, then the returned reader waits for
+// data appended to the file instead of returning io.EOF
+// until ctx is done.
+func openFile(ctx context.Context, path string, follow bool) (io.ReadCloser, error) {
+	file, err := os.Open(path) //#nosec G304 -- reading files passed by the user is the purpose
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(path, ".gz") {
+		if follow {
+			file.Close()
+			return nil, fmt.Errorf("can't follow compressed file %q", path)
+		}
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return &gzipFile{Reader: gz, file: file}, nil
+	}
+	if follow {
+		return &followReader{ctx: ctx, path: path, file: file}, nil
+	}
+	return file, nil
+}
+
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	return errors.Join(g.Reader.Close(), g.file.Close())
+}
+
+// followReader reads a file like tail -F.
+// At the end of the file it polls for appended data,
+// and re-opens the path if the file was rotated or truncated.
+// It returns io.EOF only when its context is done.
+type followReader struct {
+	ctx    context.Context
+	path   string
+	file   *os.File
+	offset int64
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.file.Read(p)
+		f.offset += int64(n)
+		if n > 0 || err != nil && !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		if f.reopenIfChanged() {
+			continue
+		}
+		select {
+		case <-f.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+// reopenIfChanged re-opens the path if it refers to another file
+// than the opened one or if the opened file was truncated.
+func (f *followReader) reopenIfChanged() bool {
+	pathInfo, err := os.Stat(f.path)
+	if err != nil {
+		// Rotated but not yet re-created
+		return false
+	}
+	fileInfo, err := f.file.Stat()
+	if err == nil && os.SameFile(pathInfo, fileInfo) && fileInfo.Size() >= f.offset {
+		return false
+	}
+	file, err := os.Open(f.path)
+	if err != nil {
+		return false
+	}
+	f.file.Close()
+	f.file = file
+	f.offset = 0
+	return true
+}
+
+func (f *followReader) Close() error {
+	return f.file.Close()
+}
+
+// readLines calls onLine with every non empty line
+// of stdin if there are no paths, else of the files at paths.
+// Only the last file is followed if options.follow is true.
+func readLines(ctx context.Context, paths []string, stdin io.Reader, options inputOptions, onLine func([]byte) error) error {
+	if len(paths) == 0 {
+		return readReaderLines(ctx, stdin, onLine)
+	}
+	files, err := inputFiles(paths, options.rotated)
+	if err != nil {
+		return err
+	}
+	for i, path := range files {
+		reader, err := openFile(ctx, path, options.follow && i == len(files)-1)
+		if err != nil {
+			return err
+		}
+		err = readReaderLines(ctx, reader, onLine)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func readReaderLines(ctx context.Context, reader io.Reader, onLine func([]byte) error) error {
+	buffered := bufio.NewReader(reader)
+	for ctx.Err() == nil {
+		line, err := buffered.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if err := onLine(line); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// recordParser returns a JSONReader used for parsing
+// single lines with the default levels and format
+// and the prefix separator of the default Format.PrefixFmt.
+func recordParser(format *golog.Format) *golog.JSONReader {
+	parser := golog.NewJSONReader(strings.NewReader(""), &golog.DefaultLevels, format)
+	parser.PrefixSep = ": "
+	return parser
+}
@@ -0,0 +1,73 @@
+/*
+Command golog reads JSON log lines as written by golog.JSONWriter
+or other structured loggers from stdin or files and renders them
+as colorized text like golog.TextWriter, or aggregates them
+with the query subcommand.
+
+Usage:
+
+	golog [cat] [flags] [files...]
+	golog query [flags] [files...]
+
+Without files stdin is read. Files ending in ".gz" are decompressed.
+With the -rotated flag the rotated files of a log file written by
+logfile.RotatingWriter (for example "app.log.2024-01-15_10:30:45")
+are read in chronological order before the file itself.
+
+Examples:
+
+	kubectl logs my-pod | golog -level warn
+	golog -rotated -since 2h -attr requestID=994d5800-afca-401f-9c2f-d9e3e106e9ef /var/log/app.log
+	golog -follow -prefix 'db*' -grep 'timeout|deadline' /var/log/app.log
+
+The query subcommand filters records with an expression,
+groups them by fields, and outputs aggregations as table or JSON:
+
+	golog query -group-by level app.log
+	golog query -where 'level >= error' -group-by message -limit 10 app.log
+	golog query -where 'prefix = http' -agg 'count,p50(duration),p99(duration)' app.log
+	golog query -group-by requestID -sort -count -format json app.log
+
+Expressions compare fields with the operators = != < <= > >= and ~ (regular expression),
+combined with and, or, not, and parentheses. The fields level, prefix, message, and time
+refer to the parsed record, all other fields to attributes.
+Levels, numbers, durations, and times are compared by value.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run executes the subcommand named by the first argument,
+// or the cat command if the first argument is not a subcommand.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "cat":
+			return runCat(ctx, args[1:], stdin, stdout, stderr)
+		case "query":
+			return runQuery(ctx, args[1:], stdin, stdout, stderr)
+		}
+	}
+	return runCat(ctx, args, stdin, stdout, stderr)
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/domonda/golog"
+)
+
+// aggregation is a column of the query result
+// calculated from the records of a group.
+type aggregation struct {
+	name       string // column name as passed
+	fn         string // count, sum, avg, min, max, or p for percentiles
+	field      string // empty for count of all records
+	percentile float64
+}
+
+// parseAggregations parses a comma separated list of
+// count, count(field), sum(field), avg(field), min(field), max(field),
+// and percentiles like p50(field) or p99.9(field).
+func parseAggregations(s string) ([]aggregation, error) {
+	var aggs []aggregation
+	for name := range strings.SplitSeq(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		agg := aggregation{name: name, fn: name}
+		if fn, rest, ok := strings.Cut(name, "("); ok {
+			fieldName, ok := strings.CutSuffix(rest, ")")
+			if !ok || fieldName == "" {
+				return nil, fmt.Errorf("invalid aggregation %q", name)
+			}
+			agg.fn, agg.field = strings.TrimSpace(fn), strings.TrimSpace(fieldName)
+		}
+		switch agg.fn {
+		case "count":
+		case "sum", "avg", "min", "max":
+			if agg.field == "" {
+				return nil, fmt.Errorf("aggregation %q needs a field like %s(duration)", name, agg.fn)
+			}
+		default:
+			p, err := strconv.ParseFloat(strings.TrimPrefix(agg.fn, "p"), 64)
+			if !strings.HasPrefix(agg.fn, "p") || err != nil || p <= 0 || p > 100 || agg.field == "" {
+				return nil, fmt.Errorf("invalid aggregation %q", name)
+			}
+			agg.fn, agg.percentile = "p", p
+		}
+		aggs = append(aggs, agg)
+	}
+	if len(aggs) == 0 {
+		return nil, fmt.Errorf("no aggregation in %q", s)
+	}
+	return aggs, nil
+}
+
+// group accumulates the records having the same group-by values
+type group struct {
+	keys      []*string // nil for missing fields
+	count     int
+	values    [][]float64 // numeric field values per aggregation
+	durations []bool      // if all values of an aggregation are durations
+}
+
+func (g *group) add(r *golog.JSONRecord, aggs []aggregation) {
+	g.count++
+	for i, agg := range aggs {
+		if agg.field == "" {
+			continue
+		}
+		f, ok := fieldOf(r, agg.field)
+		if !ok {
+			continue
+		}
+		if agg.fn == "count" {
+			g.values[i] = append(g.values[i], 1)
+			continue
+		}
+		switch f.kind {
+		case numberField:
+			g.durations[i] = false
+		case durationField:
+		default:
+			continue
+		}
+		g.values[i] = append(g.values[i], f.num)
+	}
+}
+
+// cell is an aggregated value of a result row
+type cell struct {
+	num        float64
+	valid      bool
+	isDuration bool
+}
+
+func (c cell) String() string {
+	switch {
+	case !c.valid:
+		return "-"
+	case c.isDuration:
+		return time.Duration(math.Round(c.num)).String()
+	default:
+		return strconv.FormatFloat(c.num, 'f', -1, 64)
+	}
+}
+
+func (c cell) MarshalJSON() ([]byte, error) {
+	switch {
+	case !c.valid:
+		return []byte("null"), nil
+	case c.isDuration:
+		return json.Marshal(c.String())
+	default:
+		return json.Marshal(c.num)
+	}
+}
+
+func (g *group) result(aggs []aggregation) []cell {
+	cells := make([]cell, len(aggs))
+	for i, agg := range aggs {
+		if agg.fn == "count" {
+			n := g.count
+			if agg.field != "" {
+				n = len(g.values[i])
+			}
+			cells[i] = cell{num: float64(n), valid: true}
+			continue
+		}
+		values := g.values[i]
+		if len(values) == 0 {
+			continue
+		}
+		c := cell{valid: true, isDuration: g.durations[i]}
+		switch agg.fn {
+		case "sum", "avg":
+			for _, v := range values {
+				c.num += v
+			}
+			if agg.fn == "avg" {
+				c.num /= float64(len(values))
+			}
+		case "min":
+			c.num = slices.Min(values)
+		case "max":
+			c.num = slices.Max(values)
+		case "p":
+			c.num = percentile(values, agg.percentile)
+		}
+		cells[i] = c
+	}
+	return cells
+}
+
+// percentile returns the p-th percentile of values
+// using the nearest-rank method. values will be sorted.
+func percentile(values []float64, p float64) float64 {
+	slices.Sort(values)
+	rank := int(math.Ceil(p / 100 * float64(len(values))))
+	return values[max(rank-1, 0)]
+}
+
+type resultRow struct {
+	keys  []*string
+	cells []cell
+}
+
+// runQuery aggregates JSON log lines
+func runQuery(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var (
+		flags   = flag.NewFlagSet("golog query", flag.ContinueOnError)
+		where   string
+		groupBy string
+		aggList string
+		sortBy  string
+		limit   int
+		format  string
+		options inputOptions
+	)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: golog query [flags] [files...]")
+		fmt.Fprintln(stderr, "Aggregates JSON log lines from stdin or files.")
+		fmt.Fprintln(stderr, "")
+		fmt.Fprintln(stderr, "Examples:")
+		fmt.Fprintln(stderr, "  golog query -group-by level app.log")
+		fmt.Fprintln(stderr, "  golog query -where 'level >= error' -group-by message -limit 10 app.log")
+		fmt.Fprintln(stderr, "  golog query -where 'duration' -agg 'count,p50(duration),p99(duration)' app.log")
+		fmt.Fprintln(stderr, "  golog query -where 'prefix = http and status >= 500' -group-by requestID -format json app.log")
+		fmt.Fprintln(stderr, "")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&where, "where", "", "filter `expression` like 'level >= warn and (prefix = db or duration > 1s)'")
+	flags.StringVar(&groupBy, "group-by", "", "comma separated `fields` to group by")
+	flags.StringVar(&aggList, "agg", "count", "comma separated aggregations: count, count(field), sum, avg, min, max, or percentiles like p99 of a field")
+	flags.StringVar(&sortBy, "sort", "", "column to sort by, prefix with - for descending order (default: first aggregation descending)")
+	flags.IntVar(&limit, "limit", 0, "maximum number of rows, 0 for all")
+	flags.StringVar(&format, "format", "table", "output format: table or json")
+	flags.BoolVar(&options.rotated, "rotated", false, "read the rotated files of every file first")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	filter, err := parseExpr(where)
+	if err != nil {
+		return err
+	}
+	aggs, err := parseAggregations(aggList)
+	if err != nil {
+		return err
+	}
+	var groupFields []string
+	for f := range strings.SplitSeq(groupBy, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			groupFields = append(groupFields, f)
+		}
+	}
+	if format != "table" && format != "json" {
+		return fmt.Errorf("invalid -format %q, expected table or json", format)
+	}
+
+	var (
+		parser = recordParser(nil)
+		groups = make(map[string]*group)
+		order  []*group
+		keyBuf []byte
+	)
+	err = readLines(ctx, flags.Args(), stdin, options, func(line []byte) error {
+		record, parseErr := parser.ParseLine(line)
+		if parseErr != nil || filter != nil && !filter.eval(record) {
+			// Lines that are not JSON are ignored
+			return nil
+		}
+		keys := make([]*string, len(groupFields))
+		keyBuf = keyBuf[:0]
+		for i, name := range groupFields {
+			if f, ok := fieldOf(record, name); ok {
+				keys[i] = &f.str
+				keyBuf = strconv.AppendQuote(keyBuf, f.str)
+			}
+			keyBuf = append(keyBuf, ',')
+		}
+		g := groups[string(keyBuf)]
+		if g == nil {
+			g = &group{
+				keys:      keys,
+				values:    make([][]float64, len(aggs)),
+				durations: make([]bool, len(aggs)),
+			}
+			for i := range g.durations {
+				g.durations[i] = true
+			}
+			groups[string(keyBuf)] = g
+			order = append(order, g)
+		}
+		g.add(record, aggs)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := make([]resultRow, len(order))
+	for i, g := range order {
+		rows[i] = resultRow{keys: g.keys, cells: g.result(aggs)}
+	}
+	if err := sortRows(rows, sortBy, groupFields, aggs); err != nil {
+		return err
+	}
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+
+	if format == "json" {
+		return writeJSONRows(stdout, rows, groupFields, aggs)
+	}
+	return writeTableRows(stdout, rows, groupFields, aggs)
+}
+
+// sortRows sorts rows by the column sortBy
+// which can be prefixed with "-" for descending order.
+// An empty sortBy sorts by the first aggregation descending.
+// Rows with equal values keep the order of their first record.
+func sortRows(rows []resultRow, sortBy string, groupFields []string, aggs []aggregation) error {
+	column, desc := strings.CutPrefix(sortBy, "-")
+	if sortBy == "" {
+		column, desc = aggs[0].name, true
+	}
+	var compare func(a, b resultRow) int
+	if i := slices.Index(groupFields, column); i >= 0 {
+		compare = func(a, b resultRow) int {
+			switch {
+			case a.keys[i] == nil || b.keys[i] == nil:
+				return cmp.Compare(boolInt(a.keys[i] != nil), boolInt(b.keys[i] != nil))
+			default:
+				return cmp.Compare(*a.keys[i], *b.keys[i])
+			}
+		}
+	} else if i := slices.IndexFunc(aggs, func(agg aggregation) bool { return agg.name == column }); i >= 0 {
+		compare = func(a, b resultRow) int {
+			if c := cmp.Compare(boolInt(a.cells[i].valid), boolInt(b.cells[i].valid)); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.cells[i].num, b.cells[i].num)
+		}
+	} else {
+		return fmt.Errorf("invalid -sort column %q", column)
+	}
+	slices.SortStableFunc(rows, func(a, b resultRow) int {
+		if desc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+	return nil
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func writeTableRows(w io.Writer, rows []resultRow, groupFields []string, aggs []aggregation) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	columns := slices.Clone(groupFields)
+	for _, agg := range aggs {
+		columns = append(columns, agg.name)
+	}
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		columns = columns[:0]
+		for _, key := range row.keys {
+			if key == nil {
+				columns = append(columns, "-")
+			} else {
+				columns = append(columns, strings.ReplaceAll(*key, "\n", `\n`))
+			}
+		}
+		for _, c := range row.cells {
+			columns = append(columns, c.String())
+		}
+		fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	}
+	return tw.Flush()
+}
+
+// writeJSONRows writes rows as JSON array of objects
+// with the group-by fields and aggregations as keys.
+func writeJSONRows(w io.Writer, rows []resultRow, groupFields []string, aggs []aggregation) error {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for r, row := range rows {
+		if r > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  {")
+		for i, field := range groupFields {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			writeJSONKeyValue(&buf, field, row.keys[i])
+		}
+		for i, agg := range aggs {
+			if i > 0 || len(groupFields) > 0 {
+				buf.WriteString(",")
+			}
+			writeJSONKeyValue(&buf, agg.name, row.cells[i])
+		}
+		buf.WriteString("}")
+	}
+	if len(rows) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func writeJSONKeyValue(buf *bytes.Buffer, key string, value any) {
+	k, _ := json.Marshal(key)
+	v, err := json.Marshal(value)
+	if err != nil {
+		v = []byte("null")
+	}
+	buf.Write(k)
+	buf.WriteString(":")
+	buf.Write(v)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/golog"
+)
+
+const queryLines = `{"time":"2024-01-02 03:04:01.000","level":"INFO","message":"http: Request","requestID":"a","status":200,"duration":"10ms"}
+{"time":"2024-01-02 03:04:02.000","level":"INFO","message":"http: Request","requestID":"b","status":200,"duration":"20ms"}
+{"time":"2024-01-02 03:04:03.000","level":"ERROR","message":"http: Request failed","requestID":"b","status":500,"duration":"1.5s"}
+{"time":"2024-01-02 03:04:04.000","level":"WARN","message":"db: Slow query","took_ms":300}
+not JSON
+{"time":"2024-01-02 03:04:05.000","level":"ERROR","message":"db: Connection lost","error":"EOF"}
+{"time":"2024-01-02 03:04:06.000","level":"ERROR","message":"db: Connection lost","error":"EOF"}
+`
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "count",
+			want: "count\n6\n",
+		},
+		{
+			name: "group by level",
+			args: []string{"-group-by", "level"},
+			want: "" +
+				"level  count\n" +
+				"ERROR  3\n" +
+				"INFO   2\n" +
+				"WARN   1\n",
+		},
+		{
+			name: "top error messages",
+			args: []string{"-where", "level >= error", "-group-by", "prefix,message", "-limit", "1"},
+			want: "" +
+				"prefix  message          count\n" +
+				"db      Connection lost  2\n",
+		},
+		{
+			name: "duration percentiles",
+			args: []string{"-where", "prefix = http", "-agg", "count,p50(duration),p99(duration),max(duration),avg(status)"},
+			want: "" +
+				"count  p50(duration)  p99(duration)  max(duration)  avg(status)\n" +
+				"3      20ms           1.5s           1.5s           300\n",
+		},
+		{
+			name: "group by missing field sorted",
+			args: []string{"-group-by", "requestID", "-agg", "count,count(status)", "-sort", "requestID"},
+			want: "" +
+				"requestID  count  count(status)\n" +
+				"-          3      0\n" +
+				"a          1      1\n" +
+				"b          2      2\n",
+		},
+		{
+			name: "json",
+			args: []string{"-where", "duration > 15ms or took_ms", "-group-by", "level", "-agg", "count,max(duration)", "-sort", "level", "-format", "json"},
+			want: "[\n" +
+				`  {"level":"ERROR","count":1,"max(duration)":"1.5s"},` + "\n" +
+				`  {"level":"INFO","count":1,"max(duration)":"20ms"},` + "\n" +
+				`  {"level":"WARN","count":1,"max(duration)":null}` + "\n" +
+				"]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, runTest(t, queryLines, append([]string{"query"}, tt.args...)...))
+		})
+	}
+}
+
+func TestQuery_errors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-where", "level >"},
+		{"-where", "level = unknown"},
+		{"-agg", "p101(duration)"},
+		{"-agg", "sum"},
+		{"-sort", "unknown"},
+		{"-format", "xml"},
+	} {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			err := run(t.Context(), append([]string{"query"}, args...), strings.NewReader(queryLines), &stdout, &stderr)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_parseExpr(t *testing.T) {
+	record := &golog.JSONRecord{
+		Level:  golog.DefaultLevels.Warn,
+		Prefix: "db",
+		Text:   "Slow query",
+	}
+	record.Attribs.Add(golog.NewString("table", "users"))
+	record.Attribs.Add(golog.NewInt("rows", 42))
+	record.Attribs.Add(golog.NewString("duration", "1.5s"))
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `level = warn`, want: true},
+		{expr: `level > warn`, want: false},
+		{expr: `level >= info && level < error`, want: true},
+		{expr: `prefix = db and message ~ "^Slow"`, want: true},
+		{expr: `msg !~ Slow`, want: false},
+		{expr: `table = "users" or missing`, want: true},
+		{expr: `not (table = users)`, want: false},
+		{expr: `!missing && rows`, want: true},
+		{expr: `missing != x`, want: true},
+		{expr: `rows > 9`, want: true},
+		{expr: `rows > 100`, want: false},
+		{expr: `duration > 999ms`, want: true},
+		{expr: `duration < 1s`, want: false},
+		{expr: `table > a`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			e, err := parseExpr(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, e.eval(record))
+		})
+	}
+
+	for _, invalid := range []string{`(level = warn`, `level = `, `= x`, `a & b`, `"unterminated`, `msg ~ "("`} {
+		_, err := parseExpr(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
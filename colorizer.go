@@ -17,6 +17,14 @@ type Colorizer interface {
 	ColorizeUUID(string) string
 }
 
+// DurationColorizer can be implemented by a Colorizer
+// to style durations written with a DurationFormat.
+// Without it durations are styled like floats
+// if the DurationFormat is a number or else like strings.
+type DurationColorizer interface {
+	ColorizeDuration(string) string
+}
+
 // NoColorizer is a no-op Colorizer returning all strings unchanged
 const NoColorizer noColorizer = 0
 
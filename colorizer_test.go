@@ -98,9 +98,13 @@ func (m mockColorizer) ColorizeFloat(s string) string  { return "[float:" + s +
 func (m mockColorizer) ColorizeString(s string) string { return "[string:" + s + "]" }
 func (m mockColorizer) ColorizeError(s string) string  { return "[error:" + s + "]" }
 func (m mockColorizer) ColorizeUUID(s string) string   { return "[uuid:" + s + "]" }
+func (m mockColorizer) ColorizeDuration(s string) string {
+	return "[duration:" + s + "]"
+}
 
 func TestCustomColorizer(t *testing.T) {
 	var _ Colorizer = mockColorizer{}
+	var _ DurationColorizer = mockColorizer{}
 
 	colorizer := mockColorizer{}
 	assert.Equal(t, "[msg:hello]", colorizer.ColorizeMsg("hello"))
@@ -116,4 +120,5 @@ func TestCustomColorizer(t *testing.T) {
 	assert.Equal(t, "[string:text]", colorizer.ColorizeString("text"))
 	assert.Equal(t, "[error:err]", colorizer.ColorizeError("err"))
 	assert.Equal(t, "[uuid:abc]", colorizer.ColorizeUUID("abc"))
+	assert.Equal(t, "[duration:1s]", colorizer.ColorizeDuration("1s"))
 }
@@ -0,0 +1,268 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MaxCommandOutputLineLength is the maximum number of bytes
+// of a line written by a command that are buffered by CommandOutput.
+// Longer lines are logged in parts.
+var MaxCommandOutputLineLength = 64 * 1024
+
+// CommandOutputOptions configures how CommandOutput
+// logs the output of a command.
+type CommandOutputOptions struct {
+	// StdoutLevel is the level of lines written to stdout
+	// if no level was detected.
+	StdoutLevel Level
+	// StderrLevel is the level of lines written to stderr
+	// if no level was detected.
+	StderrLevel Level
+	// DetectLevel enables detecting the level of lines
+	// from prefixes like "[ERROR]" or "WARN:" like LevelDetectingWriter.
+	DetectLevel bool
+	// ParseJSON enables logging lines that are JSON objects
+	// with the fields of the object as attribs.
+	// The fields "msg" or "message", "level", "lvl" or "severity",
+	// and "time", "ts", or "timestamp" are used
+	// as message text, level, and timestamp.
+	ParseJSON bool
+}
+
+// DefaultCommandOutputOptions returns options logging stdout lines
+// with the info level and stderr lines with the warn level of levels
+// with level detection enabled and JSON parsing disabled.
+func DefaultCommandOutputOptions(levels *Levels) *CommandOutputOptions {
+	return &CommandOutputOptions{
+		StdoutLevel: levels.Info,
+		StderrLevel: levels.Warn,
+		DetectLevel: true,
+	}
+}
+
+// CommandOutput logs the stdout and stderr output of an exec.Cmd
+// line by line as messages of a Logger with the attribs
+// "cmd" (base name of the command path), "pid", and "stream" ("stdout" or "stderr"),
+// and logs the exit status and duration of the command
+// if it was started using the Start or Run methods and waited for using Wait.
+//
+// Example:
+//
+//	cmd := exec.CommandContext(ctx, "convert", args...)
+//	err := log.NewCommandOutput(ctx, cmd, nil).Run()
+type CommandOutput struct {
+	logger  *Logger
+	ctx     context.Context
+	cmd     *exec.Cmd
+	name    string
+	options CommandOutputOptions
+	stdout  *commandOutputWriter
+	stderr  *commandOutputWriter
+	start   time.Time
+}
+
+// NewCommandOutput returns a CommandOutput for cmd
+// and sets its writers as cmd.Stdout and cmd.Stderr.
+// The passed ctx is used for all messages.
+// If options is nil, then DefaultCommandOutputOptions
+// with the levels of the logger are used.
+func (l *Logger) NewCommandOutput(ctx context.Context, cmd *exec.Cmd, options *CommandOutputOptions) *CommandOutput {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if options == nil && l != nil {
+		options = DefaultCommandOutputOptions(l.config.Levels())
+	}
+	c := &CommandOutput{
+		logger: l,
+		ctx:    ctx,
+		cmd:    cmd,
+		name:   filepath.Base(cmd.Path),
+	}
+	if options != nil {
+		c.options = *options
+	}
+	c.stdout = &commandOutputWriter{output: c, stream: "stdout", level: c.options.StdoutLevel}
+	c.stderr = &commandOutputWriter{output: c, stream: "stderr", level: c.options.StderrLevel}
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+	return c
+}
+
+// Stdout returns the io.Writer used as cmd.Stdout
+func (c *CommandOutput) Stdout() io.Writer {
+	return c.stdout
+}
+
+// Stderr returns the io.Writer used as cmd.Stderr
+func (c *CommandOutput) Stderr() io.Writer {
+	return c.stderr
+}
+
+// Start starts the command like exec.Cmd.Start
+// and remembers the start time for the duration
+// logged by Wait.
+func (c *CommandOutput) Start() error {
+	c.start = time.Now()
+	return c.cmd.Start()
+}
+
+// Wait waits for the command to exit like exec.Cmd.Wait,
+// logs lines not terminated by a newline,
+// and logs the exit of the command with the attribs
+// "exitCode", "duration" if started with Start, and "error".
+// A command exiting with an error is logged with error level,
+// else with info level.
+func (c *CommandOutput) Wait() error {
+	err := c.cmd.Wait()
+	c.stdout.flush()
+	c.stderr.flush()
+	if c.logger == nil {
+		return err
+	}
+
+	level := c.logger.Config().InfoLevel()
+	if err != nil {
+		level = c.logger.Config().ErrorLevel()
+	}
+	m := c.newMessage(time.Now(), level, "Command exited")
+	if state := c.cmd.ProcessState; state != nil {
+		m.Int("exitCode", state.ExitCode())
+	}
+	if !c.start.IsZero() {
+		m.Duration("duration", time.Since(c.start))
+	}
+	if err != nil {
+		m.Err(err)
+	}
+	m.Log()
+	return err
+}
+
+// Run starts the command and waits for it to exit
+// like exec.Cmd.Run, see Start and Wait.
+func (c *CommandOutput) Run() error {
+	if err := c.Start(); err != nil {
+		if c.logger == nil {
+			return err
+		}
+		c.newMessage(time.Now(), c.logger.Config().ErrorLevel(), "Command start failed").Err(err).Log()
+		return err
+	}
+	return c.Wait()
+}
+
+func (c *CommandOutput) newMessage(timestamp time.Time, level Level, text string) *Message {
+	m := c.logger.NewMessageAt(c.ctx, timestamp, level, text).Str("cmd", c.name)
+	if c.cmd.Process != nil {
+		m.Int("pid", c.cmd.Process.Pid)
+	}
+	return m
+}
+
+func (c *CommandOutput) logLine(stream string, level Level, line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if c.logger == nil || len(line) == 0 {
+		return
+	}
+	var (
+		timestamp = time.Now()
+		text      = string(line)
+		isJSON    bool
+		fields    []jsonField
+	)
+	if c.options.ParseJSON && line[0] == '{' {
+		if parsed, err := parseJSONFields(line); err == nil {
+			isJSON = true
+			text = ""
+			for _, f := range parsed {
+				switch f.key {
+				case "msg", "message":
+					if s, ok := f.stringValue(); ok && text == "" {
+						text = s
+						continue
+					}
+				case "level", "lvl", "severity":
+					if s, ok := f.stringValue(); ok {
+						if l, ok := levelOfNameOrAlias(c.logger.Config().Levels(), s); ok {
+							level = l
+							continue
+						}
+					}
+				case "time", "ts", "timestamp":
+					if s, ok := f.stringValue(); ok {
+						if t, err := ParseTimestamp(s); err == nil {
+							timestamp = t.Time
+							continue
+						}
+					}
+				}
+				fields = append(fields, f)
+			}
+		}
+	}
+	if !isJSON && c.options.DetectLevel {
+		if l, t, ok := detectLevel(c.logger.Config().Levels(), text); ok {
+			level, text = l, t
+		}
+	}
+	m := c.newMessage(timestamp, level, text).Str("stream", stream)
+	for _, f := range fields {
+		m.jsonValue(f.key, f.value)
+	}
+	m.Log()
+}
+
+// commandOutputWriter splits the output of a command stream into lines
+type commandOutputWriter struct {
+	output *CommandOutput
+	stream string
+	level  Level
+
+	mutex   sync.Mutex
+	pending []byte
+}
+
+func (w *commandOutputWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	n := len(data)
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			w.pending = append(w.pending, data...)
+			for len(w.pending) >= MaxCommandOutputLineLength {
+				w.output.logLine(w.stream, w.level, w.pending[:MaxCommandOutputLineLength])
+				w.pending = append(w.pending[:0], w.pending[MaxCommandOutputLineLength:]...)
+			}
+			break
+		}
+		if len(w.pending) > 0 {
+			w.pending = append(w.pending, data[:i]...)
+			w.output.logLine(w.stream, w.level, w.pending)
+			w.pending = w.pending[:0]
+		} else {
+			w.output.logLine(w.stream, w.level, data[:i])
+		}
+		data = data[i+1:]
+	}
+	return n, nil
+}
+
+// flush logs a pending line not terminated by a newline
+func (w *commandOutputWriter) flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.pending) > 0 {
+		w.output.logLine(w.stream, w.level, w.pending)
+		w.pending = w.pending[:0]
+	}
+}
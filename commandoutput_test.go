@@ -0,0 +1,101 @@
+package golog
+
+import (
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandOutput(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	type record struct {
+		level   Level
+		text    string
+		attribs Attribs
+	}
+	var (
+		mutex   sync.Mutex
+		records []record
+	)
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewCallbackWriterConfig(func(timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		records = append(records, record{level: level, text: text, attribs: attribs.Clone()})
+	}))
+
+	options := DefaultCommandOutputOptions(&DefaultLevels)
+	options.ParseJSON = true
+	cmd := exec.Command("sh", "-c", strings.Join([]string{
+		`echo "Hello"`,
+		`echo "[ERROR] Failed" >&2`,
+		`echo '{"level":"debug","msg":"From JSON","n":1,"ok":true}'`,
+		`echo "Warning to stderr" >&2`,
+		`printf "Unterminated"`,
+		`exit 3`,
+	}, "; "))
+	output := NewLogger(config).NewCommandOutput(t.Context(), cmd, options)
+	err := output.Run()
+	require.Error(t, err)
+
+	// stdout and stderr are written concurrently,
+	// so only check the order within a stream
+	find := func(text string) record {
+		t.Helper()
+		for _, r := range records {
+			if r.text == text {
+				return r
+			}
+		}
+		t.Fatalf("message %q not logged in %v", text, records)
+		return record{}
+	}
+	pid := int64(cmd.Process.Pid)
+
+	r := find("Hello")
+	assert.Equal(t, DefaultLevels.Info, r.level)
+	assert.Equal(t, "sh", r.attribs.Get("cmd").Value())
+	assert.Equal(t, pid, r.attribs.Get("pid").Value())
+	assert.Equal(t, "stdout", r.attribs.Get("stream").Value())
+
+	r = find("Failed")
+	assert.Equal(t, DefaultLevels.Error, r.level)
+	assert.Equal(t, "stderr", r.attribs.Get("stream").Value())
+
+	r = find("From JSON")
+	assert.Equal(t, DefaultLevels.Debug, r.level)
+	assert.Equal(t, int64(1), r.attribs.Get("n").Value())
+	assert.Equal(t, true, r.attribs.Get("ok").Value())
+	assert.False(t, r.attribs.Has("level"))
+
+	r = find("Warning to stderr")
+	assert.Equal(t, DefaultLevels.Warn, r.level)
+
+	r = find("Unterminated")
+	assert.Equal(t, DefaultLevels.Info, r.level)
+
+	r = records[len(records)-1]
+	assert.Equal(t, "Command exited", r.text)
+	assert.Equal(t, DefaultLevels.Error, r.level)
+	assert.Equal(t, int64(3), r.attribs.Get("exitCode").Value())
+	assert.True(t, r.attribs.Has("duration"))
+	assert.True(t, r.attribs.Has("error"))
+}
+
+func TestCommandOutput_startError(t *testing.T) {
+	var texts []string
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewCallbackWriterConfig(func(timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+		texts = append(texts, text)
+	}))
+
+	err := NewLogger(config).NewCommandOutput(t.Context(), exec.Command("/nonexistent/command"), nil).Run()
+	require.Error(t, err)
+	assert.Equal(t, []string{"Command start failed"}, texts)
+}
@@ -0,0 +1,255 @@
+package golog
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	_ WriterConfig = new(DedupWriterConfig)
+	_ Writer       = new(dedupWriter)
+)
+
+// Keys of the attribs added to the message written by DedupWriterConfig
+// for a burst of repeated messages.
+const (
+	DedupRepeatedKey  = "repeated"
+	DedupFirstTimeKey = "firstTime"
+	DedupLastTimeKey  = "lastTime"
+)
+
+// DedupWriterConfig is a WriterConfig that wraps another WriterConfig
+// and collapses bursts of repeated messages like errors logged in retry loops.
+//
+// Messages are identical if they have the same level, prefix, text,
+// and values of the attribs with the keys passed to NewDedupWriterConfig.
+// Other attribs are not compared.
+//
+// The first message of a burst is written immediately,
+// the following identical messages are suppressed.
+// When the burst ends, a single message with the text
+// "<text> (repeated N times)" or "<text> (repeated 1 time)" is written with the attribs
+// of the last suppressed message plus DedupRepeatedKey
+// with the number of suppressed messages,
+// and DedupFirstTimeKey and DedupLastTimeKey with the
+// timestamps of the first and last message of the burst.
+//
+// Without a window a burst ends with the next different message
+// that the wrapped WriterConfig would write. Wrapped configs
+// implementing LevelDecider like TextWriterConfig and JSONWriterConfig
+// are asked before a message is recorded.
+// With a window, identical messages following within the window
+// of the previous one are suppressed, even if other messages were
+// logged in between, and the burst ends with the first message
+// logged more than the window after the last repeated message.
+// Timestamps of the messages are compared, no timer is used,
+// so bursts ending without following messages are written
+// by FlushUnderlying.
+type DedupWriterConfig struct {
+	wrapped []WriterConfig // single element slice for writeRecordedMessageTo
+	window  time.Duration
+	keys    []string
+
+	mutex     sync.Mutex
+	entries   map[string]*dedupEntry
+	lastSweep time.Time
+}
+
+// dedupEntry is the first message of a possible burst
+type dedupEntry struct {
+	ctx      context.Context
+	config   Config
+	level    Level
+	prefix   string
+	text     string
+	attribs  Attribs // of the last suppressed message
+	first    time.Time
+	last     time.Time
+	repeated int
+}
+
+// NewDedupWriterConfig returns a DedupWriterConfig writing to wrapped
+// that suppresses identical messages logged consecutively
+// if window is zero or within window of each other
+// comparing the attribs with the passed keys.
+// Panics if wrapped is nil.
+func NewDedupWriterConfig(wrapped WriterConfig, window time.Duration, keys ...string) *DedupWriterConfig {
+	if wrapped == nil {
+		panic("golog.DedupWriterConfig needs a WriterConfig to wrap") // Panic during setup is acceptable
+	}
+	return &DedupWriterConfig{
+		wrapped: []WriterConfig{wrapped},
+		window:  window,
+		keys:    keys,
+		entries: make(map[string]*dedupEntry),
+	}
+}
+
+// IsActive implements the LevelDecider interface
+// by asking the wrapped WriterConfig.
+func (c *DedupWriterConfig) IsActive(ctx context.Context, level Level) bool {
+	return WriterConfigIsActive(ctx, c.wrapped[0], level)
+}
+
+// WriterForNewMessage returns nil for messages that the wrapped
+// WriterConfig would not write according to its LevelDecider
+// implementation, so they can't end bursts.
+func (c *DedupWriterConfig) WriterForNewMessage(ctx context.Context, level Level) Writer {
+	if !c.IsActive(ctx, level) {
+		return nil
+	}
+	w := dedupWriterPool.GetOrNew()
+	w.config = c
+	w.ctx = ctx
+	return w
+}
+
+// FlushUnderlying writes the messages of all pending bursts
+// and flushes the wrapped WriterConfig.
+func (c *DedupWriterConfig) FlushUnderlying() {
+	c.mutex.Lock()
+	entries := slices.SortedFunc(maps.Values(c.entries), func(a, b *dedupEntry) int {
+		return a.last.Compare(b.last)
+	})
+	for _, e := range entries {
+		c.endBurst(e)
+	}
+	clear(c.entries)
+	c.mutex.Unlock()
+
+	c.wrapped[0].FlushUnderlying()
+}
+
+// commit writes the message recorded by w
+// or suppresses it if it is a repeated message.
+func (c *DedupWriterConfig) commit(w *dedupWriter) {
+	key := c.messageKey(w)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.window <= 0 {
+		// Any other message ends a burst
+		for k, e := range c.entries {
+			if k != key {
+				c.endBurst(e)
+				delete(c.entries, k)
+			}
+		}
+	} else if w.timestamp.Sub(c.lastSweep) >= c.window {
+		// Remove expired entries not more often than once per window
+		c.lastSweep = w.timestamp
+		var expired []string
+		for k, e := range c.entries {
+			if w.timestamp.Sub(e.last) > c.window {
+				expired = append(expired, k)
+			}
+		}
+		slices.SortFunc(expired, func(a, b string) int {
+			return c.entries[a].last.Compare(c.entries[b].last)
+		})
+		for _, k := range expired {
+			c.endBurst(c.entries[k])
+			delete(c.entries, k)
+		}
+	}
+
+	if e := c.entries[key]; e != nil {
+		if c.window <= 0 || w.timestamp.Sub(e.last) <= c.window {
+			e.repeated++
+			e.last = w.timestamp
+			e.attribs.Free()
+			e.attribs, w.attribs = w.attribs, nil
+			return
+		}
+		c.endBurst(e)
+	}
+
+	writeRecordedMessageTo(w.ctx, w.loggerConfig, c.wrapped, w.timestamp, w.level, w.prefix, w.text, w.attribs)
+	c.entries[key] = &dedupEntry{
+		ctx:    w.ctx,
+		config: w.loggerConfig,
+		level:  w.level,
+		prefix: w.prefix,
+		text:   w.text,
+		first:  w.timestamp,
+		last:   w.timestamp,
+	}
+}
+
+// endBurst writes the message for the suppressed repeats of e if there are any
+// and frees the attribs of e.
+func (c *DedupWriterConfig) endBurst(e *dedupEntry) {
+	if e.repeated > 0 {
+		attribs := e.attribs
+		attribs.Add(NewInt(DedupRepeatedKey, int64(e.repeated)))
+		attribs.Add(NewTime(DedupFirstTimeKey, e.first))
+		attribs.Add(NewTime(DedupLastTimeKey, e.last))
+		text := fmt.Sprintf("%s (repeated %d times)", e.text, e.repeated)
+		if e.repeated == 1 {
+			text = e.text + " (repeated 1 time)"
+		}
+		writeRecordedMessageTo(e.ctx, e.config, c.wrapped, e.last, e.level, e.prefix, text, attribs)
+		e.attribs = attribs
+	}
+	e.attribs.Free()
+	e.attribs = nil
+}
+
+// messageKey returns a string identifying the level, prefix, text,
+// and the compared attribs of the message recorded by w.
+func (c *DedupWriterConfig) messageKey(w *dedupWriter) string {
+	buf := make([]byte, 0, 64+len(w.prefix)+len(w.text))
+	buf = fmt.Appendf(buf, "%d\x00%s\x00%s", w.level, w.prefix, w.text)
+	for _, key := range c.keys {
+		buf = append(buf, 0)
+		if attrib := w.attribs.Get(key); attrib != nil {
+			buf = attrib.AppendJSON(buf)
+		}
+	}
+	return string(buf)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// dedupWriter records a message for DedupWriterConfig
+type dedupWriter struct {
+	config       *DedupWriterConfig
+	ctx          context.Context
+	loggerConfig Config
+	timestamp    time.Time
+	level        Level
+	prefix       string
+	text         string
+
+	attribsRecorder
+}
+
+func (w *dedupWriter) BeginMessage(config Config, timestamp time.Time, level Level, prefix, text string) {
+	w.loggerConfig = config
+	w.timestamp = timestamp
+	w.level = level
+	w.prefix = prefix
+	w.text = text
+}
+
+func (w *dedupWriter) writeAnyDuration(val time.Duration) {
+	w.recordAnyDuration(val)
+}
+
+func (w *dedupWriter) CommitMessage() {
+	w.config.commit(w)
+
+	w.attribs.Free()
+	var zero dedupWriter
+	*w = zero
+	dedupWriterPool.PutBack(w)
+}
+
+func (w *dedupWriter) String() string {
+	return "golog.DedupWriterConfig: " + w.text
+}
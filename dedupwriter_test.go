@@ -0,0 +1,102 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupWriterConfig(t *testing.T) {
+	var buf bytes.Buffer
+	format := &Format{TimestampFormat: "15:04:05", PrefixFmt: "%s: %s", TimeFormat: "15:04:05"}
+	dedup := NewDedupWriterConfig(NewTextWriterConfig(&buf, format, NoColorizer), 0, "host")
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, dedup))
+
+	at := func(sec int) time.Time { return time.Date(2024, 1, 2, 3, 4, sec, 0, time.UTC) }
+	logErr := func(sec int, host string, attempt int) {
+		log.NewMessageAt(t.Context(), at(sec), DefaultLevels.Error, "Connect failed").
+			Str("host", host).
+			Int("attempt", attempt).
+			Err(errors.New("refused")).
+			Log()
+	}
+
+	logErr(1, "a", 1)
+	logErr(2, "a", 2)
+	logErr(3, "a", 3)
+	logErr(4, "b", 4)
+	log.NewMessageAt(t.Context(), at(5), DefaultLevels.Info, "Done").Log()
+	log.NewMessageAt(t.Context(), at(6), DefaultLevels.Info, "Done").Log()
+
+	assert.Equal(t, ""+
+		"03:04:01 |ERROR| Connect failed host=\"a\" attempt=1 error=`refused`\n"+
+		"03:04:03 |ERROR| Connect failed (repeated 2 times) host=\"a\" attempt=3 error=`refused` repeated=2 firstTime=\"03:04:01\" lastTime=\"03:04:03\"\n"+
+		"03:04:04 |ERROR| Connect failed host=\"b\" attempt=4 error=`refused`\n"+
+		"03:04:05 |INFO | Done\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	log.Flush()
+	assert.Equal(t, "03:04:06 |INFO | Done (repeated 1 time) repeated=1 firstTime=\"03:04:05\" lastTime=\"03:04:06\"\n", buf.String())
+
+	buf.Reset()
+	log.Flush()
+	assert.Empty(t, buf.String(), "nothing pending after flush")
+}
+
+func TestDedupWriterConfig_window(t *testing.T) {
+	var buf bytes.Buffer
+	format := &Format{TimestampFormat: "15:04:05", TimeFormat: "15:04:05"}
+	dedup := NewDedupWriterConfig(NewTextWriterConfig(&buf, format, NoColorizer), 10*time.Second)
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, dedup))
+
+	logAt := func(sec int, text string) {
+		log.NewMessageAt(t.Context(), time.Date(2024, 1, 2, 3, 4, sec, 0, time.UTC), DefaultLevels.Warn, text).Log()
+	}
+	logAt(0, "Retry")
+	logAt(1, "Other")
+	logAt(5, "Retry")
+	logAt(14, "Retry")
+	logAt(30, "Retry")
+	logAt(31, "Retry")
+
+	assert.Equal(t, ""+
+		"03:04:00 |WARN | Retry\n"+
+		"03:04:01 |WARN | Other\n"+
+		"03:04:14 |WARN | Retry (repeated 2 times) repeated=2 firstTime=\"03:04:00\" lastTime=\"03:04:14\"\n"+
+		"03:04:30 |WARN | Retry\n",
+		buf.String(),
+	)
+
+	buf.Reset()
+	dedup.FlushUnderlying()
+	assert.Equal(t, "03:04:31 |WARN | Retry (repeated 1 time) repeated=1 firstTime=\"03:04:30\" lastTime=\"03:04:31\"\n", buf.String())
+}
+
+func TestDedupWriterConfig_wrappedFilter(t *testing.T) {
+	var buf bytes.Buffer
+	format := &Format{TimestampFormat: "15:04:05", TimeFormat: "15:04:05"}
+	errorsOnly := NewTextWriterConfig(&buf, format, NoColorizer, DefaultLevels.Error.FilterOutBelow())
+	dedup := NewDedupWriterConfig(errorsOnly, 0)
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, dedup))
+
+	for sec := range 3 {
+		at := time.Date(2024, 1, 2, 3, 4, sec, 0, time.UTC)
+		log.NewMessageAt(t.Context(), at, DefaultLevels.Error, "Failed").Log()
+		// Debug messages not written by the wrapped config don't end the burst
+		log.NewMessageAt(t.Context(), at, DefaultLevels.Debug, "Retrying").Log()
+	}
+	log.Flush()
+
+	assert.Equal(t, ""+
+		"03:04:00 |ERROR| Failed\n"+
+		"03:04:02 |ERROR| Failed (repeated 2 times) repeated=2 firstTime=\"03:04:00\" lastTime=\"03:04:02\"\n",
+		buf.String(),
+	)
+	assert.False(t, dedup.IsActive(t.Context(), DefaultLevels.Debug))
+	assert.True(t, dedup.IsActive(t.Context(), DefaultLevels.Error))
+}
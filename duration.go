@@ -0,0 +1,125 @@
+package golog
+
+import (
+	"strconv"
+	"time"
+)
+
+// DurationFormat selects how writers format [time.Duration] values
+// logged with [Message.Duration] via [Format.DurationFormat].
+type DurationFormat string
+
+const (
+	// DurationFormatString formats durations as string
+	// using [time.Duration.String] like "1m30.5s".
+	// An empty DurationFormat is treated like DurationFormatString.
+	DurationFormatString DurationFormat = "string"
+
+	// DurationFormatISO8601 formats durations as ISO 8601 duration string
+	// with hours as largest unit like "PT1M30.5S".
+	// Negative durations get a leading minus sign like "-PT1S".
+	DurationFormatISO8601 DurationFormat = "iso8601"
+
+	// DurationFormatSeconds formats durations as floating point number of seconds like 90.5.
+	DurationFormatSeconds DurationFormat = "seconds"
+
+	// DurationFormatNanoseconds formats durations as integer number of nanoseconds like 90500000000.
+	DurationFormatNanoseconds DurationFormat = "nanoseconds"
+)
+
+// IsNumber returns true if durations are formatted as numbers
+// instead of strings.
+func (f DurationFormat) IsNumber() bool {
+	return f == DurationFormatSeconds || f == DurationFormatNanoseconds
+}
+
+// Value returns val formatted as string,
+// or as float64 seconds for DurationFormatSeconds,
+// or as int64 nanoseconds for DurationFormatNanoseconds.
+func (f DurationFormat) Value(val time.Duration) any {
+	switch f {
+	case DurationFormatSeconds:
+		return val.Seconds()
+	case DurationFormatNanoseconds:
+		return int64(val)
+	}
+	return string(f.AppendDuration(nil, val))
+}
+
+// AppendDuration appends val formatted according to f to buf.
+// Strings are appended without quotes.
+func (f DurationFormat) AppendDuration(buf []byte, val time.Duration) []byte {
+	switch f {
+	case DurationFormatISO8601:
+		return appendISO8601Duration(buf, val)
+	case DurationFormatSeconds:
+		return strconv.AppendFloat(buf, val.Seconds(), 'f', -1, 64)
+	case DurationFormatNanoseconds:
+		return strconv.AppendInt(buf, int64(val), 10)
+	}
+	return append(buf, val.String()...)
+}
+
+func appendISO8601Duration(buf []byte, val time.Duration) []byte {
+	if val == 0 {
+		return append(buf, "PT0S"...)
+	}
+	// Use uint64 to handle the minimum duration without overflow
+	u := uint64(val)
+	if val < 0 {
+		buf = append(buf, '-')
+		u = -u
+	}
+	buf = append(buf, 'P', 'T')
+
+	hours := u / uint64(time.Hour)
+	u -= hours * uint64(time.Hour)
+	minutes := u / uint64(time.Minute)
+	u -= minutes * uint64(time.Minute)
+	seconds := u / uint64(time.Second)
+	nanos := u - seconds*uint64(time.Second)
+
+	if hours > 0 {
+		buf = strconv.AppendUint(buf, hours, 10)
+		buf = append(buf, 'H')
+	}
+	if minutes > 0 {
+		buf = strconv.AppendUint(buf, minutes, 10)
+		buf = append(buf, 'M')
+	}
+	if seconds > 0 || nanos > 0 {
+		buf = strconv.AppendUint(buf, seconds, 10)
+		if nanos > 0 {
+			// Append 9 fraction digits and trim trailing zeros
+			buf = append(buf, '.')
+			start := len(buf)
+			buf = strconv.AppendUint(buf, nanos+uint64(time.Second), 10)
+			buf = append(buf[:start], buf[start+1:]...) // remove leading 1
+			for buf[len(buf)-1] == '0' {
+				buf = buf[:len(buf)-1]
+			}
+		}
+		buf = append(buf, 'S')
+	}
+	return buf
+}
+
+// anyDurationWriter is implemented by writers that decide
+// how a time.Duration logged with Message.Any is written:
+// writers with a Format that can set a DurationFormat,
+// writers wrapping other writers, and writers recording
+// attribs that are written later by other writers.
+type anyDurationWriter interface {
+	writeAnyDuration(val time.Duration)
+}
+
+// writeAnyDuration writes a time.Duration logged with Message.Any
+// as integer nanoseconds like its underlying int64 type
+// unless w decides otherwise by implementing anyDurationWriter.
+func writeAnyDuration(w Writer, val time.Duration) {
+	if a, ok := w.(anyDurationWriter); ok {
+		a.writeAnyDuration(val)
+		return
+	}
+	w.WriteInt(int64(val))
+}
@@ -0,0 +1,139 @@
+package golog
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationFormat_AppendDuration(t *testing.T) {
+	tests := []struct {
+		format DurationFormat
+		val    time.Duration
+		want   string
+	}{
+		{"", 90*time.Second + 500*time.Millisecond, "1m30.5s"},
+		{DurationFormatString, 0, "0s"},
+		{DurationFormatISO8601, 0, "PT0S"},
+		{DurationFormatISO8601, 90*time.Second + 500*time.Millisecond, "PT1M30.5S"},
+		{DurationFormatISO8601, 26 * time.Hour, "PT26H"},
+		{DurationFormatISO8601, time.Hour + time.Nanosecond, "PT1H0.000000001S"},
+		{DurationFormatISO8601, -1500 * time.Millisecond, "-PT1.5S"},
+		{DurationFormatISO8601, math.MinInt64, "-PT2562047H47M16.854775808S"},
+		{DurationFormatSeconds, 1500 * time.Millisecond, "1.5"},
+		{DurationFormatSeconds, 0, "0"},
+		{DurationFormatNanoseconds, 1500 * time.Millisecond, "1500000000"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format)+"/"+tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(tt.format.AppendDuration(nil, tt.val)))
+		})
+	}
+}
+
+func TestDurationFormat_Value(t *testing.T) {
+	assert.Equal(t, "1.5s", DurationFormatString.Value(1500*time.Millisecond))
+	assert.Equal(t, "PT1.5S", DurationFormatISO8601.Value(1500*time.Millisecond))
+	assert.Equal(t, 1.5, DurationFormatSeconds.Value(1500*time.Millisecond))
+	assert.Equal(t, int64(1500000000), DurationFormatNanoseconds.Value(1500*time.Millisecond))
+}
+
+func TestMessage_Duration(t *testing.T) {
+	for _, tt := range []struct {
+		format   DurationFormat
+		wantText string
+		wantJSON string
+	}{
+		// Without DurationFormat Any logs the int64 nanoseconds of time.Duration
+		{"", `took="1.5s" all=["1s","2m0s"] any=1500000000`, `"took":"1.5s","all":["1s","2m0s"],"any":1500000000`},
+		{DurationFormatString, `took="1.5s" all=["1s","2m0s"] any="1.5s"`, `"took":"1.5s","all":["1s","2m0s"],"any":"1.5s"`},
+		{DurationFormatISO8601, `took="PT1.5S" all=["PT1S","PT2M"] any="PT1.5S"`, `"took":"PT1.5S","all":["PT1S","PT2M"],"any":"PT1.5S"`},
+		{DurationFormatSeconds, `took=1.5 all=[1,120] any=1.5`, `"took":1.5,"all":[1,120],"any":1.5`},
+		{DurationFormatNanoseconds, `took=1500000000 all=[1000000000,120000000000] any=1500000000`, `"took":1500000000,"all":[1000000000,120000000000],"any":1500000000`},
+	} {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var textBuf, jsonBuf bytes.Buffer
+			format := &Format{DurationFormat: tt.format}
+			log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+				NewTextWriterConfig(&textBuf, format, NoColorizer),
+				NewJSONWriterConfig(&jsonBuf, format),
+			))
+
+			log.Info("Done").
+				Duration("took", 1500*time.Millisecond).
+				Durations("all", []time.Duration{time.Second, 2 * time.Minute}).
+				Any("any", 1500*time.Millisecond).
+				Log()
+
+			assert.Equal(t, " |INFO | Done "+tt.wantText+"\n", textBuf.String())
+			assert.Equal(t, "{"+tt.wantJSON+"}\n", jsonBuf.String())
+		})
+	}
+}
+
+func TestMessage_Duration_recordingWriters(t *testing.T) {
+	var dedupBuf, flightBuf bytes.Buffer
+	format := &Format{DurationFormat: DurationFormatISO8601}
+	recorder := NewFlightRecorder(
+		NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&flightBuf, format, NoColorizer)),
+		1,
+		DefaultLevels.Error,
+	)
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewDedupWriterConfig(NewTextWriterConfig(&dedupBuf, format, NoColorizer), 0),
+		recorder,
+	))
+
+	log.Info("Done").
+		Any("any", 1500*time.Millisecond).
+		Log()
+	log.Error("Failed").Log()
+	log.Flush()
+
+	// Recorded durations are written with the DurationFormat of the target writers
+	assert.Equal(t, " |INFO | Done any=\"PT1.5S\"\n |ERROR| Failed\n", dedupBuf.String())
+	assert.Equal(t, " |INFO | Done any=\"PT1.5S\"\n", flightBuf.String())
+}
+
+func TestTextWriter_Duration_colorizer(t *testing.T) {
+	// Wrapping hides the optional DurationColorizer of mockColorizer
+	colorizer := struct{ Colorizer }{mockColorizer{}}
+	for _, tt := range []struct {
+		format DurationFormat
+		want   string
+	}{
+		{DurationFormatString, `[key:took]=[string:"1.5s"]`},
+		{DurationFormatSeconds, `[key:took]=[float:1.5]`},
+	} {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+				NewTextWriterConfig(&buf, &Format{DurationFormat: tt.format}, colorizer),
+			))
+			log.Info("Done").Duration("took", 1500*time.Millisecond).Log()
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestCallbackWriter_Duration(t *testing.T) {
+	var recorded Attribs
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewCallbackWriterConfig(
+		func(timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+			recorded = attribs.Clone()
+		},
+	))
+	NewLogger(config).Info("Done").
+		Duration("took", time.Second).
+		Durations("all", []time.Duration{time.Second, time.Minute}).
+		Log()
+
+	require.Len(t, recorded, 2)
+	assert.Equal(t, time.Second, recorded[0].(*Duration).Value())
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, recorded[1].(*Durations).Value())
+	assert.Equal(t, `"took":"1s"`, string(recorded[0].AppendJSON(nil)))
+}
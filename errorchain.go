@@ -0,0 +1,180 @@
+package golog
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-encjson"
+)
+
+// MaxErrorChainDepth limits the depth of the cause tree
+// walked by NewErrorChain to protect against pathological
+// error implementations.
+const MaxErrorChainDepth = 32
+
+// ErrorChain is the structured representation of an error
+// with its type, the attribs contributed by the error
+// if it implements Loggable, and its causes
+// returned by an Unwrap() error or Unwrap() []error method.
+//
+// Writers that implement ErrorChainWriter render it structured,
+// all other writers get the original error passed to WriteError.
+type ErrorChain struct {
+	// Err is the original error
+	Err error
+	// Message is the result of Err.Error()
+	Message string
+	// Type is the Go type of Err like "*fs.PathError"
+	Type string
+	// Attribs logged by Err if it implements Loggable
+	Attribs Attribs
+	// Causes are the unwrapped errors of Err as ErrorChains
+	Causes []*ErrorChain
+}
+
+// NewErrorChain returns the ErrorChain of err
+// or nil if err is nil.
+func NewErrorChain(err error) *ErrorChain {
+	return newErrorChain(err, 0)
+}
+
+func newErrorChain(err error, depth int) *ErrorChain {
+	if err == nil {
+		return nil
+	}
+	chain := &ErrorChain{
+		Err:     err,
+		Message: err.Error(),
+		Type:    reflect.TypeOf(err).String(),
+	}
+	if loggable, ok := err.(Loggable); ok {
+		m := newMessage(nil, nil, nil, 0, "")
+		loggable.Log(m)
+		chain.Attribs = m.attribs
+		m.attribs = nil
+		m.reset()
+		messagePool.PutBack(m)
+	}
+	if depth >= MaxErrorChainDepth {
+		return chain
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := newErrorChain(x.Unwrap(), depth+1); cause != nil {
+			chain.Causes = []*ErrorChain{cause}
+		}
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if cause := newErrorChain(e, depth+1); cause != nil {
+				chain.Causes = append(chain.Causes, cause)
+			}
+		}
+	}
+	return chain
+}
+
+// Error implements the error interface
+// by returning the message of the original error.
+func (c *ErrorChain) Error() string {
+	return c.Message
+}
+
+// Unwrap returns the original error
+// so errors.Is and errors.As work with an ErrorChain.
+func (c *ErrorChain) Unwrap() error {
+	return c.Err
+}
+
+// String returns the chain in the format
+// written by TextWriter without colors.
+func (c *ErrorChain) String() string {
+	w := TextWriter{config: &TextWriterConfig{format: NewDefaultFormat(), colorizer: NoColorizer, noColorizer: true}}
+	w.writeErrorChain(c)
+	return string(w.buf)
+}
+
+// AppendJSON appends the chain as JSON object
+// in the format written by JSONWriter to buf.
+func (c *ErrorChain) AppendJSON(buf []byte) []byte {
+	buf = encjson.AppendObjectStart(buf)
+	buf = encjson.AppendKey(buf, "message")
+	buf = encjson.AppendString(buf, c.Message)
+	buf = encjson.AppendKey(buf, "type")
+	buf = encjson.AppendString(buf, c.Type)
+	if len(c.Attribs) > 0 {
+		buf = encjson.AppendKey(buf, "attribs")
+		buf = c.Attribs.AppendJSON(buf)
+	}
+	if len(c.Causes) > 0 {
+		buf = encjson.AppendKey(buf, "causes")
+		buf = encjson.AppendArrayStart(buf)
+		for _, cause := range c.Causes {
+			buf = cause.AppendJSON(buf)
+		}
+		buf = encjson.AppendArrayEnd(buf)
+	}
+	return encjson.AppendObjectEnd(buf)
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// using the format of AppendJSON.
+func (c *ErrorChain) MarshalJSON() ([]byte, error) {
+	return c.AppendJSON(nil), nil
+}
+
+// ErrorChainWriter can be implemented by a Writer
+// to write an ErrorChain in a structured way
+// instead of the error message string written by WriteError.
+type ErrorChainWriter interface {
+	WriteErrorChain(chain *ErrorChain)
+}
+
+// ErrorChain logs err as ErrorChain with the passed key.
+// Writers implementing ErrorChainWriter like JSONWriter and TextWriter
+// write the error message, type, attribs, and the tree of
+// unwrapped causes, all other writers write the error like Message.Error.
+func (m *Message) ErrorChain(key string, err error) *Message {
+	if m.skipKey(&key) {
+		return m
+	}
+	if err == nil {
+		return m.writeNil(key)
+	}
+	chain, ok := err.(*ErrorChain)
+	if !ok {
+		chain = NewErrorChain(err)
+	}
+	if m.IsAttribRecorder() {
+		m.attribs.Add(NewAny(key, chain))
+		return m
+	}
+	for _, w := range m.writers {
+		w.WriteKey(key)
+		writeErrorChain(w, chain)
+	}
+	return m
+}
+
+// ErrChain is a shortcut for ErrorChain("error", err)
+func (m *Message) ErrChain(err error) *Message {
+	return m.ErrorChain("error", err)
+}
+
+func writeErrorChain(w Writer, chain *ErrorChain) {
+	if cw, ok := w.(ErrorChainWriter); ok {
+		cw.WriteErrorChain(chain)
+	} else {
+		w.WriteError(chain.Err)
+	}
+}
+
+// writeErrorChainAttribs writes the attribs of an ErrorChain
+// to a Writer that renders them nested in its own output.
+func writeErrorChainAttribs(w Writer, attribs Attribs) {
+	if len(attribs) == 0 {
+		return
+	}
+	m := newMessage(nil, nil, []Writer{w}, 0, "")
+	attribs.Log(m)
+	m.reset()
+	messagePool.PutBack(m)
+}
@@ -0,0 +1,124 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testQueryError struct {
+	query string
+	code  int
+}
+
+func (e *testQueryError) Error() string { return "query failed" }
+
+func (e *testQueryError) Log(m *Message) {
+	m.Str("query", e.query).Int("code", e.code)
+}
+
+func ExampleMessage_ErrorChain() {
+	format := &Format{
+		TimestampFormat: "2006-01-02 15:04:05",
+		TimestampKey:    "time",
+		LevelKey:        "level",
+		MessageKey:      "message",
+	}
+	config := NewConfig(
+		&DefaultLevels,
+		AllLevelsActive,
+		NewTextWriterConfig(os.Stdout, format, NoColorizer),
+		NewJSONWriterConfig(os.Stdout, format),
+	)
+	log := NewLogger(config)
+
+	// Use fixed time for reproducable example output
+	at, _ := time.Parse("2006-01-02 15:04:05", "2006-01-02 15:04:05")
+
+	err := fmt.Errorf("loading user: %w", &testQueryError{query: "SELECT 1", code: 42})
+	log.NewMessageAt(context.Background(), at, config.ErrorLevel(), "Failed").
+		ErrChain(err).
+		Log()
+
+	// Output:
+	// 2006-01-02 15:04:05 |ERROR| Failed error=`loading user: query failed` (*fmt.wrapError) <- `query failed` (*golog.testQueryError query="SELECT 1" code=42)
+	// {"time":"2006-01-02 15:04:05","level":"ERROR","message":"Failed","error":{"message":"loading user: query failed","type":"*fmt.wrapError","causes":[{"message":"query failed","type":"*golog.testQueryError","attribs":{"query":"SELECT 1","code":42}}]}}
+}
+
+func TestNewErrorChain(t *testing.T) {
+	assert.Nil(t, NewErrorChain(nil))
+
+	queryErr := &testQueryError{query: "q", code: 1}
+	err := fmt.Errorf("outer: %w", errors.Join(queryErr, fs.ErrNotExist))
+	chain := NewErrorChain(err)
+
+	assert.Equal(t, err.Error(), chain.Error())
+	assert.Equal(t, "*fmt.wrapError", chain.Type)
+	require.Len(t, chain.Causes, 1)
+	join := chain.Causes[0]
+	assert.Equal(t, "*errors.joinError", join.Type)
+	require.Len(t, join.Causes, 2)
+	assert.Equal(t, "*golog.testQueryError", join.Causes[0].Type)
+	assert.Equal(t, "q", join.Causes[0].Attribs.Get("query").Value())
+	assert.Equal(t, "*errors.errorString", join.Causes[1].Type)
+
+	assert.ErrorIs(t, chain, fs.ErrNotExist)
+	var target *testQueryError
+	assert.ErrorAs(t, chain, &target)
+
+	assert.Equal(t,
+		"`\nouter: query failed\nfile does not exist\n` (*fmt.wrapError) <- `\nquery failed\nfile does not exist\n` (*errors.joinError) <- [`query failed` (*golog.testQueryError query=\"q\" code=1), `file does not exist` (*errors.errorString)]",
+		chain.String(),
+	)
+}
+
+func TestErrorChain_AppendJSON(t *testing.T) {
+	chain := NewErrorChain(fmt.Errorf("outer: %w", &testQueryError{query: "q", code: 1}))
+
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{MessageKey: "message"})))
+	log.Error("Failed").ErrChain(chain).Log()
+
+	var written struct {
+		Error json.RawMessage `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &written))
+	assert.JSONEq(t, string(chain.AppendJSON(nil)), string(written.Error), "JSONWriter and AppendJSON are consistent")
+
+	marshalled, err := json.Marshal(chain)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(written.Error), string(marshalled))
+}
+
+func TestMessage_ErrorChain_recorded(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer))
+	log := NewLogger(config).With().
+		ErrChain(fmt.Errorf("outer: %w", errors.New("inner"))).
+		SubLogger()
+
+	log.Info("Message").Ints("ints", []int{1, 2}).Log()
+	assert.Equal(t, " |INFO | Message error=`outer: inner` (*fmt.wrapError) <- `inner` (*errors.errorString) ints=[1,2]\n", buf.String())
+}
+
+func TestMessage_ErrorChain_fallback(t *testing.T) {
+	var gotAttribs Attribs
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewCallbackWriterConfig(func(timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+		gotAttribs = attribs.Clone()
+	}))
+	err := fmt.Errorf("outer: %w", errors.New("inner"))
+	NewLogger(config).Error("Failed").ErrChain(err).ErrorChain("nil", nil).Log()
+
+	require.Len(t, gotAttribs, 2)
+	assert.Equal(t, NewError("error", err), gotAttribs[0])
+	assert.Equal(t, NewNil("nil"), gotAttribs[1])
+}
@@ -27,17 +27,18 @@ func main() {
 		DebugLevelColor: color.New(color.FgMagenta),
 		TraceLevelColor: color.New(color.FgHiBlack),
 
-		MsgColor:    color.New(color.FgHiWhite),
-		KeyColor:    color.New(color.FgCyan),
-		NilColor:    color.New(color.FgWhite),
-		TrueColor:   color.New(color.FgGreen),
-		FalseColor:  color.New(color.FgYellow),
-		IntColor:    color.New(color.FgWhite),
-		UintColor:   color.New(color.FgWhite),
-		FloatColor:  color.New(color.FgWhite),
-		UUIDColor:   color.New(color.FgWhite),
-		StringColor: color.New(color.FgWhite),
-		ErrorColor:  color.New(color.FgRed),
+		MsgColor:      color.New(color.FgHiWhite),
+		KeyColor:      color.New(color.FgCyan),
+		NilColor:      color.New(color.FgWhite),
+		TrueColor:     color.New(color.FgGreen),
+		FalseColor:    color.New(color.FgYellow),
+		IntColor:      color.New(color.FgWhite),
+		UintColor:     color.New(color.FgWhite),
+		FloatColor:    color.New(color.FgWhite),
+		UUIDColor:     color.New(color.FgWhite),
+		DurationColor: color.New(color.FgHiBlue),
+		StringColor:   color.New(color.FgWhite),
+		ErrorColor:    color.New(color.FgRed),
 	}
 
 	printMessages(consoleColorizer)
@@ -94,6 +95,7 @@ type ConsoleColorizer struct {
 	StringColor     *color.Color
 	ErrorColor      *color.Color
 	UUIDColor       *color.Color
+	DurationColor   *color.Color
 }
 
 func (c *ConsoleColorizer) ColorizeMsg(str string) string {
@@ -203,3 +205,10 @@ func (c *ConsoleColorizer) ColorizeUUID(str string) string {
 	}
 	return c.UUIDColor.Sprint(str)
 }
+
+func (c *ConsoleColorizer) ColorizeDuration(str string) string {
+	if c.DurationColor == nil {
+		return str
+	}
+	return c.DurationColor.Sprint(str)
+}
@@ -0,0 +1,263 @@
+package golog
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	_ WriterConfig = new(FlightRecorder)
+	_ Writer       = new(flightRecorderWriter)
+	_ Writer       = flightRecorderTrigger{}
+)
+
+// FlightRecorder is a WriterConfig that keeps the last messages
+// of the recorded levels in memory without writing them
+// and writes them to the writers of a target Config
+// only when a message with the trigger level or above is logged.
+//
+// This gives full debug detail exactly when something fails
+// at a low steady-state cost.
+//
+// A FlightRecorder can be added to the writers of a Config
+// to record globally or to a context with ContextWithFlightRecorder
+// to record per request or any other unit of work.
+//
+// The recorded messages are written before the triggering message
+// is committed, so they precede it if the target Config
+// writes to the same output as the triggering logger.
+//
+// The recorded messages are written to all writers of the target Config
+// that are active for the recorded level, independent of the
+// level filter of the target Config itself,
+// so writers of the target Config should not filter out
+// the recorded levels.
+type FlightRecorder struct {
+	target   Config
+	trigger  Level
+	filter   LevelFilter
+	mutex    sync.Mutex
+	records  []*flightRecorderWriter // Ring buffer
+	next     int                     // Index of the next record in the ring buffer
+	numAdded int                     // Number of records in the ring buffer
+}
+
+// NewFlightRecorder returns a new FlightRecorder that keeps
+// the last capacity messages of levels below trigger
+// that are not filtered out by the passed filters
+// and writes them to the writers of target when a message
+// with a level of trigger or above is logged.
+//
+// Pass for example DefaultLevels.Debug.FilterOutAbove()
+// as filter to record only TRACE and DEBUG messages.
+//
+// Panics if target is nil or capacity is not positive.
+func NewFlightRecorder(target Config, capacity int, trigger Level, filters ...LevelFilter) *FlightRecorder {
+	if target == nil {
+		panic("golog.FlightRecorder target must not be nil") // Panic during setup is acceptable
+	}
+	if capacity <= 0 {
+		panic("golog.FlightRecorder capacity must be positive") // Panic during setup is acceptable
+	}
+	return &FlightRecorder{
+		target:  target,
+		trigger: trigger,
+		filter:  JoinLevelFilters(filters...),
+		records: make([]*flightRecorderWriter, capacity),
+	}
+}
+
+// ContextWithFlightRecorder returns a context with a new FlightRecorder
+// added as additional WriterConfig using ContextWithAdditionalWriterConfigs.
+// See NewFlightRecorder for the meaning of the arguments.
+//
+// Only messages logged with the returned context or contexts
+// derived from it are recorded, so this can be used to
+// record the debug messages of a single request
+// and write them only if an error was logged for that request.
+func ContextWithFlightRecorder(ctx context.Context, target Config, capacity int, trigger Level, filters ...LevelFilter) context.Context {
+	return ContextWithAdditionalWriterConfigs(ctx, NewFlightRecorder(target, capacity, trigger, filters...))
+}
+
+func (r *FlightRecorder) WriterForNewMessage(ctx context.Context, level Level) Writer {
+	if level >= r.trigger {
+		return flightRecorderTrigger{recorder: r}
+	}
+	if r.filter.IsInactive(ctx, level) {
+		return nil
+	}
+	w := flightRecorderWriterPool.GetOrNew()
+	w.recorder = r
+	return w
+}
+
+// FlushUnderlying flushes the writers of the target Config.
+// It does not write the recorded messages, use Dump for that.
+func (r *FlightRecorder) FlushUnderlying() {
+	for _, w := range r.target.WriterConfigs() {
+		w.FlushUnderlying()
+	}
+}
+
+// Len returns the number of currently recorded messages.
+func (r *FlightRecorder) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.numAdded
+}
+
+// Dump writes all recorded messages in the order they were logged
+// to the writers of the target Config and removes them from the recorder.
+//
+// Dump is called automatically when a message with the trigger level
+// or above is started, so the recorded messages are written
+// before the triggering message is committed to any writer.
+func (r *FlightRecorder) Dump() {
+	for _, rec := range r.takeRecords() {
+		writeRecordedMessage(context.Background(), r.target, rec.timestamp, rec.level, rec.prefix, rec.text, rec.attribs)
+		rec.free()
+	}
+}
+
+// Reset removes all recorded messages without writing them.
+func (r *FlightRecorder) Reset() {
+	for _, rec := range r.takeRecords() {
+		rec.free()
+	}
+}
+
+// takeRecords removes all records from the ring buffer
+// and returns them in the order they were added.
+func (r *FlightRecorder) takeRecords() []*flightRecorderWriter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.numAdded == 0 {
+		return nil
+	}
+	records := make([]*flightRecorderWriter, 0, r.numAdded)
+	first := r.next - r.numAdded
+	if first < 0 {
+		first += len(r.records)
+	}
+	for i := range r.numAdded {
+		index := (first + i) % len(r.records)
+		records = append(records, r.records[index])
+		r.records[index] = nil
+	}
+	r.next = 0
+	r.numAdded = 0
+	return records
+}
+
+// add adds a record to the ring buffer,
+// freeing the oldest record if the buffer is full.
+func (r *FlightRecorder) add(rec *flightRecorderWriter) {
+	r.mutex.Lock()
+	evicted := r.records[r.next]
+	r.records[r.next] = rec
+	r.next = (r.next + 1) % len(r.records)
+	if r.numAdded < len(r.records) {
+		r.numAdded++
+	}
+	r.mutex.Unlock()
+
+	if evicted != nil {
+		evicted.free()
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// flightRecorderWriter records a message including its attribs
+// and is kept as record in the ring buffer of its FlightRecorder
+// after CommitMessage.
+type flightRecorderWriter struct {
+	recorder  *FlightRecorder
+	timestamp time.Time
+	level     Level
+	prefix    string
+	text      string
+
+	attribsRecorder
+}
+
+func (w *flightRecorderWriter) BeginMessage(config Config, timestamp time.Time, level Level, prefix, text string) {
+	w.timestamp = timestamp
+	w.level = level
+	w.prefix = prefix
+	w.text = text
+}
+
+func (w *flightRecorderWriter) CommitMessage() {
+	w.recorder.add(w)
+}
+
+func (w *flightRecorderWriter) writeAnyDuration(val time.Duration) {
+	w.recordAnyDuration(val)
+}
+
+func (w *flightRecorderWriter) String() string {
+	return "golog.FlightRecorder: " + w.text
+}
+
+func (w *flightRecorderWriter) free() {
+	w.attribs.Free()
+	var zero flightRecorderWriter
+	*w = zero
+	flightRecorderWriterPool.PutBack(w)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// flightRecorderTrigger is the Writer returned by FlightRecorder
+// for messages with the trigger level or above.
+// It ignores the message itself and dumps the recorder
+// when the message is begun, because the other writers
+// of the message, which may write to the same output
+// as the target Config, only write it when committed.
+type flightRecorderTrigger struct {
+	NopWriter
+	recorder *FlightRecorder
+}
+
+func (t flightRecorderTrigger) BeginMessage(Config, time.Time, Level, string, string) {
+	t.recorder.Dump()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// writeRecordedMessage writes a message with already recorded attribs
+// to all writers of config that return a Writer for the level.
+// The level filter of config itself is not checked.
+func writeRecordedMessage(ctx context.Context, config Config, timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+	writeRecordedMessageTo(ctx, config, config.WriterConfigs(), timestamp, level, prefix, text, attribs)
+}
+
+// writeRecordedMessageTo writes a message with already recorded attribs
+// to all writerConfigs that return a Writer for the level
+// passing config to Writer.BeginMessage.
+func writeRecordedMessageTo(ctx context.Context, config Config, writerConfigs []WriterConfig, timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+	var writersArray [4]Writer
+	writers := writersArray[:0]
+	for _, writerConfig := range writerConfigs {
+		if w := writerConfig.WriterForNewMessage(ctx, level); w != nil {
+			w.BeginMessage(config, timestamp, level, prefix, text)
+			writers = append(writers, w)
+		}
+	}
+	if len(writers) == 0 {
+		return
+	}
+	m := newMessage(nil, nil, writers, level, text)
+	attribs.Log(m)
+	// Not using m.Log() because an already recorded
+	// message should not trigger GlobalPanicLevel again
+	for _, w := range m.writers {
+		w.CommitMessage()
+	}
+	m.reset()
+	messagePool.PutBack(m)
+}
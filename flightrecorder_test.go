@@ -0,0 +1,95 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	var written []string
+	target := NewConfig(
+		&DefaultLevels,
+		AllLevelsActive,
+		NewCallbackWriterConfig(func(timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+			written = append(written, fmt.Sprintf("%s %s %s", DefaultLevels.Name(level), text, attribs))
+		}),
+	)
+	recorder := NewFlightRecorder(target, 2, DefaultLevels.Error, DefaultLevels.Debug.FilterOutAbove())
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, recorder))
+
+	log.Debug("first").Int("n", 1).Log()
+	log.Debug("second").Int("n", 2).Log()
+	log.Trace("third").Strs("s", []string{"a", "b"}).Log()
+	log.Info("filtered out").Log()
+	assert.Equal(t, 2, recorder.Len(), "capacity limits recorded messages")
+	assert.Empty(t, written, "nothing written before trigger")
+
+	log.Error("trigger").Err(errors.New("failed")).Log()
+	require.Equal(t, 0, recorder.Len())
+	assert.Equal(t, []string{
+		`DEBUG second [Int{"n": 2}]`,
+		`TRACE third [Strings{"s": []string{"a", "b"}}]`,
+	}, written)
+
+	written = nil
+	log.Debug("reset").Log()
+	recorder.Reset()
+	log.Error("trigger").Log()
+	assert.Empty(t, written)
+}
+
+func TestFlightRecorder_dumpBeforeTrigger(t *testing.T) {
+	var buf bytes.Buffer
+	target := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer))
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewTextWriterConfig(&buf, &Format{}, NoColorizer, DefaultLevels.Info.FilterOutBelow()),
+	))
+
+	ctx := ContextWithFlightRecorder(t.Context(), target, 10, DefaultLevels.Error, DefaultLevels.Debug.FilterOutAbove())
+	log.DebugCtx(ctx, "recorded").Log()
+	log.InfoCtx(ctx, "written").Log()
+	log.ErrorCtx(ctx, "trigger").Log()
+
+	// The recorded messages are written before the triggering message
+	// because the recorder dumps when the message is begun
+	assert.Equal(t, ""+
+		" |INFO | written\n"+
+		" |DEBUG| recorded\n"+
+		" |ERROR| trigger\n",
+		buf.String(),
+	)
+}
+
+func TestContextWithFlightRecorder(t *testing.T) {
+	var written []string
+	target := NewConfig(
+		&DefaultLevels,
+		AllLevelsActive,
+		NewCallbackWriterConfig(func(timestamp time.Time, level Level, prefix, text string, attribs Attribs) {
+			written = append(written, DefaultLevels.Name(level)+" "+prefix+text)
+		}),
+	)
+	log := NewLoggerWithPrefix(NewConfig(&DefaultLevels, AllLevelsActive, NopWriterConfig("nop")), "prefix: ")
+
+	// Without recorder in context nothing is recorded
+	// and no message is triggering a dump
+	log.Debug("not recorded").Log()
+	log.Error("no dump").Log()
+	assert.Empty(t, written)
+
+	ctx := ContextWithFlightRecorder(context.Background(), target, 10, DefaultLevels.Error)
+	log.DebugCtx(ctx, "recorded").Log()
+	log.InfoCtx(ctx, "also recorded").Log()
+	log.ErrorCtx(ctx, "dump").Log()
+	assert.Equal(t, []string{
+		"DEBUG prefix: recorded",
+		"INFO prefix: also recorded",
+	}, written)
+}
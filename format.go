@@ -46,12 +46,23 @@ type Format struct {
 	// log line timestamp ([Format.TimestampFormat]).
 	TimeFormat string
 
+	// DurationFormat selects how [time.Duration] values logged with
+	// [Message.Duration] are formatted. If empty, [DurationFormatString] is used.
+	// A non empty DurationFormat also applies to durations logged with
+	// [Message.Any], that are otherwise logged as integer nanoseconds.
+	DurationFormat DurationFormat
+
 	// Location, when not nil, converts every formatted time value to this
 	// location via [time.Time.In] before formatting. It applies to both the
 	// log line timestamp ([Format.TimestampFormat]) and structured [time.Time]
 	// attributes ([Format.TimeFormat]). When nil, times are formatted in their
 	// original location.
 	Location *time.Location
+
+	// Limits for the size of logged values and messages
+	// enforced by [TextWriter] and [JSONWriter].
+	// The zero value does not limit anything.
+	Limits Limits
 }
 
 // NewDefaultFormat returns a pointer to a [Format] with common defaults:
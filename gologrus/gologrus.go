@@ -0,0 +1,173 @@
+/*
+Package gologrus provides a logrus.Hook and a logrus.Formatter
+that route the entries of github.com/sirupsen/logrus to a golog.Logger.
+
+This way code using logrus, for example vendored libraries,
+shares the writers and formats of golog.
+
+# Basic Usage
+
+Redirect configures a logrus.Logger to log only via golog:
+
+	import (
+		"github.com/sirupsen/logrus"
+
+		"github.com/domonda/golog"
+		"github.com/domonda/golog/gologrus"
+	)
+
+	gologLogger := golog.NewLogger(config)
+	gologrus.Redirect(logrus.StandardLogger(), gologLogger, gologrus.ConvertDefaultLevels)
+	logrus.WithField("key", "value").Info("Hello from logrus")
+
+Use a Hook to log to golog in addition to the output of logrus:
+
+	logrus.AddHook(gologrus.NewHook(gologLogger, gologrus.ConvertDefaultLevels))
+
+# Conversion
+
+The fields of an entry are logged sorted by key.
+Error values like the one added by WithError are logged as golog errors
+and all other values like by golog.Message.Any.
+The context of an entry is passed to golog.
+
+If the golog.Logger captures source code locations
+(see golog.Logger.WithSource) then the caller of an entry
+of a logrus.Logger with ReportCaller enabled is logged as source location.
+*/
+package gologrus
+
+import (
+	"context"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/domonda/golog"
+)
+
+var (
+	_ logrus.Hook      = new(Hook)
+	_ logrus.Formatter = new(Formatter)
+)
+
+// ConvertLevelFunc converts a logrus.Level to a golog.Level.
+// Custom conversion functions can be provided to NewHook and NewFormatter
+// if different level mapping is required.
+type ConvertLevelFunc func(logrus.Level) golog.Level
+
+// ConvertDefaultLevels converts logrus log levels to golog levels:
+//   - logrus.PanicLevel and logrus.FatalLevel → golog.DefaultLevels.Fatal
+//   - logrus.ErrorLevel → golog.DefaultLevels.Error
+//   - logrus.WarnLevel → golog.DefaultLevels.Warn
+//   - logrus.InfoLevel → golog.DefaultLevels.Info
+//   - logrus.DebugLevel → golog.DefaultLevels.Debug
+//   - logrus.TraceLevel and above → golog.DefaultLevels.Trace and below
+//
+// Levels outside the valid golog range return golog.LevelInvalid.
+func ConvertDefaultLevels(l logrus.Level) golog.Level {
+	var i int
+	switch l {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		i = int(golog.DefaultLevels.Fatal)
+	case logrus.ErrorLevel:
+		i = int(golog.DefaultLevels.Error)
+	case logrus.WarnLevel:
+		i = int(golog.DefaultLevels.Warn)
+	case logrus.InfoLevel:
+		i = int(golog.DefaultLevels.Info)
+	case logrus.DebugLevel:
+		i = int(golog.DefaultLevels.Debug)
+	default:
+		i = int(golog.DefaultLevels.Trace) - int(l-logrus.TraceLevel) //#nosec G115 -- logrus.Level is a small uint32
+	}
+	if i < int(golog.LevelMin) || i > int(golog.LevelMax) {
+		return golog.LevelInvalid
+	}
+	return golog.Level(i)
+}
+
+// Hook is a logrus.Hook that logs all entries to a golog.Logger
+// in addition to the output of the logrus.Logger.
+type Hook struct {
+	logger       *golog.Logger
+	convertLevel ConvertLevelFunc
+}
+
+// NewHook returns a Hook logging to logger
+// using convertLevel to map logrus levels to golog levels.
+// Use ConvertDefaultLevels for golog.DefaultLevels.
+func NewHook(logger *golog.Logger, convertLevel ConvertLevelFunc) *Hook {
+	return &Hook{logger: logger, convertLevel: convertLevel}
+}
+
+// Levels implements logrus.Hook by returning all levels.
+// Filtering is done by the golog.Logger.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	logEntry(h.logger, h.convertLevel, entry)
+	return nil
+}
+
+// Formatter is a logrus.Formatter that logs entries
+// to a golog.Logger instead of formatting them.
+// It returns no bytes so nothing is written
+// to the output of the logrus.Logger.
+type Formatter struct {
+	logger       *golog.Logger
+	convertLevel ConvertLevelFunc
+}
+
+// NewFormatter returns a Formatter logging to logger
+// using convertLevel to map logrus levels to golog levels.
+// Use ConvertDefaultLevels for golog.DefaultLevels.
+func NewFormatter(logger *golog.Logger, convertLevel ConvertLevelFunc) *Formatter {
+	return &Formatter{logger: logger, convertLevel: convertLevel}
+}
+
+// Format implements logrus.Formatter.
+func (f *Formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	logEntry(f.logger, f.convertLevel, entry)
+	return nil, nil
+}
+
+// Redirect configures l to log all entries only to logger
+// by setting a Formatter, discarding the output of l,
+// and enabling all levels of l so that filtering
+// is done by logger.
+func Redirect(l *logrus.Logger, logger *golog.Logger, convertLevel ConvertLevelFunc) {
+	l.SetFormatter(NewFormatter(logger, convertLevel))
+	l.SetOutput(io.Discard)
+	l.SetLevel(logrus.TraceLevel)
+}
+
+func logEntry(logger *golog.Logger, convertLevel ConvertLevelFunc, entry *logrus.Entry) {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	level := convertLevel(entry.Level)
+	var source golog.Source
+	if entry.Caller != nil && logger.SourceEnabled() {
+		source = golog.Source{Function: entry.Caller.Function, File: entry.Caller.File, Line: entry.Caller.Line}
+	}
+	m := logger.NewMessageWithSource(ctx, entry.Time, level, entry.Message, source)
+	if m == nil {
+		return
+	}
+	for _, key := range slices.Sorted(maps.Keys(entry.Data)) {
+		switch val := entry.Data[key].(type) {
+		case error:
+			m.Error(key, val)
+		default:
+			m.Any(key, val)
+		}
+	}
+	m.Log()
+}
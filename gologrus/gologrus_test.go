@@ -0,0 +1,93 @@
+package gologrus
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/golog"
+)
+
+func TestConvertDefaultLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		l    logrus.Level
+		want golog.Level
+	}{
+		{name: "Panic", l: logrus.PanicLevel, want: golog.DefaultLevels.Fatal},
+		{name: "Fatal", l: logrus.FatalLevel, want: golog.DefaultLevels.Fatal},
+		{name: "Error", l: logrus.ErrorLevel, want: golog.DefaultLevels.Error},
+		{name: "Warn", l: logrus.WarnLevel, want: golog.DefaultLevels.Warn},
+		{name: "Info", l: logrus.InfoLevel, want: golog.DefaultLevels.Info},
+		{name: "Debug", l: logrus.DebugLevel, want: golog.DefaultLevels.Debug},
+		{name: "Trace", l: logrus.TraceLevel, want: golog.DefaultLevels.Trace},
+		{name: "Trace+1", l: logrus.TraceLevel + 1, want: golog.DefaultLevels.Trace - 1},
+		{name: "Invalid", l: logrus.TraceLevel + 100, want: golog.LevelInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertDefaultLevels(tt.l))
+		})
+	}
+}
+
+func newTestLogger(buf *bytes.Buffer, filter golog.LevelFilter) *golog.Logger {
+	return golog.NewLogger(golog.NewConfig(&golog.DefaultLevels, filter, golog.NewJSONWriterConfig(buf, golog.NewDefaultFormat())))
+}
+
+func TestRedirect(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logrus.New()
+	Redirect(logger, newTestLogger(&buf, golog.DefaultLevels.Info.FilterOutBelow()), ConvertDefaultLevels)
+
+	logger.Debug("Filtered")
+	assert.Empty(t, buf.String())
+
+	logger.
+		WithContext(golog.ContextWithAttribs(t.Context(), golog.NewString("requestID", "r1"))).
+		WithError(errors.New("timeout")).
+		WithFields(logrus.Fields{"rows": 3, "table": "a"}).
+		Warn("Query failed")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), buf.String())
+	assert.NotEmpty(t, record["time"])
+	delete(record, "time")
+	assert.Equal(t, map[string]any{
+		"level":     "WARN",
+		"message":   "Query failed",
+		"requestID": "r1",
+		"error":     "timeout",
+		"rows":      float64(3),
+		"table":     "a",
+	}, record)
+	// Fields are sorted by key
+	assert.Regexp(t, `"error".*"rows".*"table"`, buf.String())
+}
+
+func TestHook(t *testing.T) {
+	var (
+		gologBuf  bytes.Buffer
+		logrusBuf bytes.Buffer
+	)
+	logger := logrus.New()
+	logger.SetOutput(&logrusBuf)
+	logger.SetReportCaller(true)
+	logger.AddHook(NewHook(newTestLogger(&gologBuf, golog.AllLevelsActive).WithSource(0), ConvertDefaultLevels))
+
+	logger.Info("Hello")
+
+	assert.Contains(t, logrusBuf.String(), "msg=Hello")
+	var record struct {
+		Message string       `json:"message"`
+		Source  golog.Source `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(gologBuf.Bytes(), &record), gologBuf.String())
+	assert.Equal(t, "Hello", record.Message)
+	assert.Equal(t, "github.com/domonda/golog/gologrus.TestHook", record.Source.Function)
+}
@@ -35,6 +35,17 @@ golog's flexible output formatting, multiple writers, and performance optimizati
 The package provides ConvertDefaultLevels to map slog levels to golog levels.
 You can provide a custom ConvertLevelFunc if you need different mapping logic.
 
+# Writing to a slog.Handler
+
+NewWriterConfig returns a golog.WriterConfig for the reverse direction
+that passes golog messages as slog.Record to any slog.Handler:
+
+	config := golog.NewConfig(
+		&golog.DefaultLevels,
+		golog.AllLevelsActive,
+		goslog.NewWriterConfig(slog.Default().Handler(), goslog.ConvertDefaultLevelsToSlog),
+	)
+
 # Compatibility
 
 This handler passes the slog.Handler test suite (slogtest.TestHandler) and
@@ -139,12 +150,21 @@ func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
 // Handle processes a slog.Record by writing it to the golog logger.
 // This method is part of the slog.Handler interface.
 //
+// If the golog logger captures source code locations (see golog.Logger.WithSource),
+// then the location of the record's PC is logged.
+//
 // The record's attributes are written to the golog message, with any
 // pre-configured attributes (from WithAttrs) written first, followed
 // by the record's own attributes. Group prefixes from WithGroup are
 // applied to attribute keys.
 func (h *handler) Handle(ctx context.Context, record slog.Record) error {
-	msg := h.logger.NewMessageAt(ctx, record.Time, h.convertLevel(record.Level), record.Message)
+	var msg *golog.Message
+	if record.PC != 0 && h.logger.SourceEnabled() {
+		source := golog.SourceFromPC(record.PC)
+		msg = h.logger.NewMessageWithSource(ctx, record.Time, h.convertLevel(record.Level), record.Message, source)
+	} else {
+		msg = h.logger.NewMessageAt(ctx, record.Time, h.convertLevel(record.Level), record.Message)
+	}
 	for _, a := range h.attrs {
 		msg = writeAttr(msg, "", a.Key, a.Value)
 	}
@@ -1,8 +1,10 @@
 package goslog
 
 import (
+	"fmt"
 	"log/slog"
 	"reflect"
+	"runtime"
 	"testing"
 	"testing/slogtest"
 
@@ -46,3 +48,17 @@ func TestConvertDefaultLevels(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Source(t *testing.T) {
+	var rec recorder
+	config := golog.NewConfig(&golog.DefaultLevels, golog.AllLevelsActive, &rec)
+
+	slog.New(Handler(golog.NewLogger(config), ConvertDefaultLevels)).Info("No source")
+	require.Len(t, rec.Result, 1)
+	require.NotContains(t, rec.Result[0], golog.SourceKey)
+
+	slog.New(Handler(golog.NewLogger(config).WithSource(0), ConvertDefaultLevels)).Info("With source")
+	_, file, line, _ := runtime.Caller(0)
+	require.Len(t, rec.Result, 2)
+	require.Equal(t, fmt.Sprintf("%s:%d", file, line-1), rec.Result[1][golog.SourceKey])
+}
@@ -179,6 +179,12 @@ func (w *recorder) WriteTime(val time.Time) {
 	w.writeVal(val)
 }
 
+// WriteDuration implements golog.Writer.
+// The duration is stored as-is.
+func (w *recorder) WriteDuration(val time.Duration) {
+	w.writeVal(val)
+}
+
 // WriteUUID implements golog.Writer.
 // The UUID is formatted as a string.
 func (w *recorder) WriteUUID(val [16]byte) {
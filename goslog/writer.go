@@ -0,0 +1,322 @@
+package goslog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/domonda/golog"
+	"github.com/domonda/golog/mempool"
+)
+
+var (
+	_ golog.WriterConfig     = new(WriterConfig)
+	_ golog.Writer           = new(Writer)
+	_ golog.ErrorChainWriter = new(Writer)
+	_ golog.SourceWriter     = new(Writer)
+)
+
+// ConvertToSlogLevelFunc converts a golog.Level to a slog.Level.
+// Custom conversion functions can be provided to NewWriterConfig
+// if different level mapping is required.
+type ConvertToSlogLevelFunc func(golog.Level) slog.Level
+
+// ConvertDefaultLevelsToSlog converts golog.DefaultLevels to slog levels.
+// It is the inverse of ConvertDefaultLevels for all levels
+// returned by ConvertDefaultLevels:
+//   - golog.DefaultLevels.Debug and below → slog.LevelDebug and below
+//   - golog.DefaultLevels.Info → slog.LevelInfo
+//   - golog.DefaultLevels.Warn → slog.LevelWarn
+//   - golog.DefaultLevels.Error and above → slog.LevelError and above
+//
+// Levels between the default levels that are not returned by ConvertDefaultLevels
+// are mapped to the range between the neighbouring slog levels.
+func ConvertDefaultLevelsToSlog(l golog.Level) slog.Level {
+	switch {
+	case l <= golog.DefaultLevels.Debug:
+		return slog.LevelDebug - slog.Level(golog.DefaultLevels.Debug-l)
+	case l <= golog.DefaultLevels.Info:
+		return max(slog.LevelInfo-slog.Level(golog.DefaultLevels.Info-l), slog.LevelDebug+1)
+	case l <= golog.DefaultLevels.Warn:
+		return max(slog.LevelWarn-slog.Level(golog.DefaultLevels.Warn-l), slog.LevelInfo+1)
+	case l <= golog.DefaultLevels.Error:
+		return max(slog.LevelError-slog.Level(golog.DefaultLevels.Error-l), slog.LevelWarn+1)
+	default:
+		return slog.LevelError + slog.Level(l-golog.DefaultLevels.Error)
+	}
+}
+
+// WriterConfig is a golog.WriterConfig that converts every golog message
+// into a slog.Record and passes it to a slog.Handler.
+//
+// This way code instrumented with golog can run inside services
+// that standardize on slog handlers.
+//
+// A non empty message prefix is prepended to the record message
+// like by the golog default format as "prefix: text".
+// UUIDs are passed as formatted strings,
+// JSON values as json.RawMessage,
+// and slices as typed Go slices.
+// Use golog.NewLimitsWriterConfig to limit the size of passed values.
+//
+// Don't pass a Handler created by this package that
+// writes to a golog.Config using the WriterConfig
+// because that would result in an endless loop.
+type WriterConfig struct {
+	handler      slog.Handler
+	convertLevel ConvertToSlogLevelFunc
+	filter       golog.LevelFilter
+}
+
+// NewWriterConfig returns a new WriterConfig passing golog messages
+// as slog.Record to handler using convertLevel to convert the levels.
+// Use ConvertDefaultLevelsToSlog for golog.DefaultLevels.
+func NewWriterConfig(handler slog.Handler, convertLevel ConvertToSlogLevelFunc, filters ...golog.LevelFilter) *WriterConfig {
+	if handler == nil {
+		panic("nil slog.Handler") // Panic during setup is acceptable
+	}
+	return &WriterConfig{
+		handler:      handler,
+		convertLevel: convertLevel,
+		filter:       golog.JoinLevelFilters(filters...),
+	}
+}
+
+// WriterForNewMessage implements golog.WriterConfig.
+// It returns nil if the level is filtered out
+// or not enabled by the slog.Handler.
+// IsActive implements the golog.LevelDecider interface
+// by returning if the filter of the config passes the level.
+func (c *WriterConfig) IsActive(ctx context.Context, level golog.Level) bool {
+	return c.filter.IsActive(ctx, level)
+}
+
+func (c *WriterConfig) WriterForNewMessage(ctx context.Context, level golog.Level) golog.Writer {
+	if c.filter.IsInactive(ctx, level) {
+		return nil
+	}
+	slogLevel := c.convertLevel(level)
+	if !c.handler.Enabled(ctx, slogLevel) {
+		return nil
+	}
+	w := writerPool.GetOrNew()
+	w.config = c
+	w.ctx = ctx
+	w.level = slogLevel
+	return w
+}
+
+// FlushUnderlying implements golog.WriterConfig.
+// slog.Handler has no flush method, so this is a no-op.
+func (c *WriterConfig) FlushUnderlying() {}
+
+var writerPool mempool.Pointer[Writer]
+
+// Writer is the golog.Writer returned by WriterConfig.
+// It collects the values of a message as slog.Attr
+// and passes the slog.Record to the slog.Handler
+// of the WriterConfig in CommitMessage.
+type Writer struct {
+	config *WriterConfig
+	ctx    context.Context
+	level  slog.Level
+	record slog.Record
+	key    string
+	slice  any // Typed slice of the current slice attribute or sliceStart
+}
+
+// BeginMessage implements golog.Writer.
+func (w *Writer) BeginMessage(config golog.Config, timestamp time.Time, level golog.Level, prefix, text string) {
+	if prefix != "" {
+		text = prefix + ": " + text
+	}
+	w.record = slog.NewRecord(timestamp, w.level, text, 0)
+}
+
+// CommitMessage implements golog.Writer.
+// It passes the record to the slog.Handler and reports
+// a returned error to golog.ErrorHandler.
+func (w *Writer) CommitMessage() {
+	err := w.config.handler.Handle(w.ctx, w.record)
+	if err != nil && golog.ErrorHandler != nil {
+		golog.ErrorHandler(fmt.Errorf("goslog.Writer error: %w", err))
+	}
+
+	*w = Writer{}
+	writerPool.PutBack(w)
+}
+
+// String implements golog.Writer.
+func (w *Writer) String() string {
+	return "goslog.Writer: " + w.record.Message
+}
+
+func (w *Writer) WriteKey(key string) {
+	w.key = key
+}
+
+func (w *Writer) WriteSliceKey(key string) {
+	w.key = key
+	w.slice = sliceStart{}
+}
+
+func (w *Writer) WriteSliceEnd() {
+	if _, empty := w.slice.(sliceStart); empty {
+		w.slice = []any{}
+	}
+	w.record.AddAttrs(slog.Any(w.key, w.slice))
+	w.slice = nil
+}
+
+func (w *Writer) WriteNil() {
+	if w.isSlice() {
+		appendToSlice[any](w, nil)
+		return
+	}
+	w.record.AddAttrs(slog.Any(w.key, nil))
+}
+
+func (w *Writer) WriteBool(val bool) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.Bool(w.key, val))
+}
+
+func (w *Writer) WriteInt(val int64) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.Int64(w.key, val))
+}
+
+func (w *Writer) WriteUint(val uint64) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.Uint64(w.key, val))
+}
+
+func (w *Writer) WriteFloat(val float64) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.Float64(w.key, val))
+}
+
+func (w *Writer) WriteString(val string) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.String(w.key, val))
+}
+
+func (w *Writer) WriteError(val error) {
+	if val == nil {
+		w.WriteNil()
+		return
+	}
+	if w.isSlice() {
+		appendToSlice(w, val.Error())
+		return
+	}
+	w.record.AddAttrs(slog.Any(w.key, val))
+}
+
+// WriteErrorChain implements golog.ErrorChainWriter
+// by passing the *golog.ErrorChain as value
+// that implements error and json.Marshaler.
+func (w *Writer) WriteErrorChain(chain *golog.ErrorChain) {
+	if w.isSlice() {
+		appendToSlice[any](w, chain)
+		return
+	}
+	w.record.AddAttrs(slog.Any(w.key, chain))
+}
+
+// WriteSource implements golog.SourceWriter
+// by passing the location as *slog.Source
+// which slog handlers render like their own source attribute.
+func (w *Writer) WriteSource(source golog.Source) {
+	src := &slog.Source{Function: source.Function, File: source.File, Line: source.Line}
+	if w.isSlice() {
+		appendToSlice[any](w, src)
+		return
+	}
+	w.record.AddAttrs(slog.Any(w.key, src))
+}
+
+func (w *Writer) WriteTime(val time.Time) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.Time(w.key, val))
+}
+
+func (w *Writer) WriteDuration(val time.Duration) {
+	if w.isSlice() {
+		appendToSlice(w, val)
+		return
+	}
+	w.record.AddAttrs(slog.Duration(w.key, val))
+}
+
+func (w *Writer) WriteUUID(val [16]byte) {
+	if w.isSlice() {
+		appendToSlice(w, golog.FormatUUID(val))
+		return
+	}
+	w.record.AddAttrs(slog.String(w.key, golog.FormatUUID(val)))
+}
+
+func (w *Writer) WriteJSON(val []byte) {
+	if len(val) == 0 {
+		val = []byte("null")
+	}
+	if w.isSlice() {
+		appendToSlice(w, json.RawMessage(val))
+		return
+	}
+	w.record.AddAttrs(slog.Any(w.key, json.RawMessage(val)))
+}
+
+// isSlice returns true if a slice attribute is being written.
+// The slice is allocated with the type of the first element.
+func (w *Writer) isSlice() bool {
+	return w.slice != nil
+}
+
+// appendToSlice appends val to the current slice of w.
+// If the slice has a different element type,
+// for example because of a nil element,
+// then it is converted to []any.
+func appendToSlice[T any](w *Writer, val T) {
+	switch s := w.slice.(type) {
+	case []T:
+		w.slice = append(s, val)
+	case []any:
+		w.slice = append(s, val)
+	case sliceStart:
+		w.slice = []T{val}
+	default:
+		rv := reflect.ValueOf(s)
+		anys := make([]any, rv.Len(), rv.Len()+1)
+		for i := range anys {
+			anys[i] = rv.Index(i).Interface()
+		}
+		w.slice = append(anys, val)
+	}
+}
+
+// sliceStart marks the beginning of a slice
+// before the first element was written.
+type sliceStart struct{}
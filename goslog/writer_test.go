@@ -0,0 +1,128 @@
+package goslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/golog"
+)
+
+func TestConvertDefaultLevelsToSlog(t *testing.T) {
+	tests := []struct {
+		name string
+		l    golog.Level
+		want slog.Level
+	}{
+		{name: "Trace", l: golog.DefaultLevels.Trace, want: slog.LevelDebug - 10},
+		{name: "Debug", l: golog.DefaultLevels.Debug, want: slog.LevelDebug},
+		{name: "Debug+1", l: golog.DefaultLevels.Debug + 1, want: slog.LevelDebug + 1},
+		{name: "Info", l: golog.DefaultLevels.Info, want: slog.LevelInfo},
+		{name: "Warn", l: golog.DefaultLevels.Warn, want: slog.LevelWarn},
+		{name: "Error", l: golog.DefaultLevels.Error, want: slog.LevelError},
+		{name: "Fatal", l: golog.DefaultLevels.Fatal, want: slog.LevelError + 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertDefaultLevelsToSlog(tt.l))
+		})
+	}
+
+	// Inverse of ConvertDefaultLevels
+	for l := slog.LevelDebug - 10; l <= slog.LevelError+10; l++ {
+		assert.Equal(t, l, ConvertDefaultLevelsToSlog(ConvertDefaultLevels(l)), "slog level %d", l)
+	}
+}
+
+func TestWriterConfig(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	config := golog.NewConfig(&golog.DefaultLevels, golog.AllLevelsActive, NewWriterConfig(handler, ConvertDefaultLevelsToSlog))
+	log := golog.NewLoggerWithPrefix(config, "pkg")
+
+	timestamp := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	uuid := golog.MustParseUUID("994d5800-afca-401f-9c2f-d9e3e106e9ef")
+
+	log.Debug("Not enabled by handler").Log()
+	assert.Empty(t, buf.String())
+
+	log.NewMessageAt(t.Context(), timestamp, golog.DefaultLevels.Warn, "Hello").
+		Nil("nil").
+		Bool("bool", true).
+		Int("int", -1).
+		Uint("uint", 1).
+		Float("float", 1.5).
+		Str("str", "x").
+		Err(errors.New("failed")).
+		Time("at", timestamp.Add(time.Hour)).
+		UUID("uuid", uuid).
+		JSON("json", []byte(`{"a":1}`)).
+		Ints("ints", []int{1, 2}).
+		Strs("strs", []string{}).
+		Errors("errs", []error{errors.New("a"), nil}).
+		UUIDs("uuids", [][16]byte{uuid}).
+		Log()
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, map[string]any{
+		"time":  "2024-01-02T03:04:05Z",
+		"level": "WARN",
+		"msg":   "pkg: Hello",
+		"nil":   nil,
+		"bool":  true,
+		"int":   float64(-1),
+		"uint":  float64(1),
+		"float": 1.5,
+		"str":   "x",
+		"error": "failed",
+		"at":    "2024-01-02T04:04:05Z",
+		"uuid":  "994d5800-afca-401f-9c2f-d9e3e106e9ef",
+		"json":  map[string]any{"a": float64(1)},
+		"ints":  []any{float64(1), float64(2)},
+		"strs":  []any{},
+		"errs":  []any{"a", nil},
+		"uuids": []any{"994d5800-afca-401f-9c2f-d9e3e106e9ef"},
+	}, record)
+}
+
+func TestWriterConfig_ErrorChain(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, nil)
+	config := golog.NewConfig(&golog.DefaultLevels, golog.AllLevelsActive, NewWriterConfig(handler, ConvertDefaultLevelsToSlog))
+
+	golog.NewLogger(config).Error("Failed").ErrChain(errors.Join(errors.New("a"))).Log()
+
+	var record struct {
+		Error struct {
+			Type   string           `json:"type"`
+			Causes []map[string]any `json:"causes"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "*errors.joinError", record.Error.Type)
+	assert.Len(t, record.Error.Causes, 1)
+}
+
+func TestWriterConfig_Source(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	config := golog.NewConfig(&golog.DefaultLevels, golog.AllLevelsActive, NewWriterConfig(handler, ConvertDefaultLevelsToSlog))
+	source := golog.Source{Function: "pkg.Func", File: "/src/file.go", Line: 7}
+
+	golog.NewLogger(config).NewMessageWithSource(t.Context(), time.Time{}, golog.DefaultLevels.Info, "Hello", source).Log()
+	assert.Equal(t, "level=INFO msg=Hello source=/src/file.go:7\n", buf.String())
+}
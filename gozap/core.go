@@ -0,0 +1,170 @@
+/*
+Package gozap provides a zapcore.Core that routes log entries
+of go.uber.org/zap to a golog.Logger.
+
+This way code using zap, for example vendored libraries,
+shares the writers and formats of golog.
+
+# Basic Usage
+
+	import (
+		"go.uber.org/zap"
+
+		"github.com/domonda/golog"
+		"github.com/domonda/golog/gozap"
+	)
+
+	gologLogger := golog.NewLogger(config)
+	logger := zap.New(gozap.Core(gologLogger, gozap.ConvertDefaultLevels))
+	logger.Info("Hello from zap", zap.String("key", "value"))
+
+# Conversion
+
+The name of a zap logger is used as golog message prefix.
+zap.Error fields are logged as golog errors,
+arrays and objects as structured values,
+and namespaces prefix the keys of the following fields
+with the namespace name and a dot like "namespace.key".
+
+If the golog.Logger captures source code locations
+(see golog.Logger.WithSource) then the caller of an entry
+added by zap.AddCaller is logged as source location.
+*/
+package gozap
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/domonda/golog"
+)
+
+// StacktraceKey is the key used for the stack trace
+// of entries logged with zap.AddStacktrace.
+const StacktraceKey = "stacktrace"
+
+// ConvertLevelFunc converts a zapcore.Level to a golog.Level.
+// Custom conversion functions can be provided to Core
+// if different level mapping is required.
+type ConvertLevelFunc func(zapcore.Level) golog.Level
+
+// ConvertDefaultLevels converts zap log levels to golog levels:
+//   - zapcore.DebugLevel and below → golog.DefaultLevels.Debug and below
+//   - zapcore.InfoLevel → golog.DefaultLevels.Info
+//   - zapcore.WarnLevel → golog.DefaultLevels.Warn
+//   - zapcore.ErrorLevel → golog.DefaultLevels.Error
+//   - zapcore.DPanicLevel → golog.DefaultLevels.Error + 1
+//   - zapcore.PanicLevel → golog.DefaultLevels.Error + 2
+//   - zapcore.FatalLevel and above → golog.DefaultLevels.Fatal and above
+//
+// Levels outside the valid golog range return golog.LevelInvalid.
+func ConvertDefaultLevels(l zapcore.Level) golog.Level {
+	var i int
+	switch {
+	case l <= zapcore.DebugLevel:
+		i = int(golog.DefaultLevels.Debug) - int(zapcore.DebugLevel-l)
+	case l == zapcore.InfoLevel:
+		i = int(golog.DefaultLevels.Info)
+	case l == zapcore.WarnLevel:
+		i = int(golog.DefaultLevels.Warn)
+	case l < zapcore.FatalLevel:
+		i = int(golog.DefaultLevels.Error) + int(l-zapcore.ErrorLevel)
+	default:
+		i = int(golog.DefaultLevels.Fatal) + int(l-zapcore.FatalLevel)
+	}
+	if i < int(golog.LevelMin) || i > int(golog.LevelMax) {
+		return golog.LevelInvalid
+	}
+	return golog.Level(i)
+}
+
+// Core returns a zapcore.Core that writes entries to logger
+// using convertLevel to map zap levels to golog levels.
+// Use ConvertDefaultLevels for golog.DefaultLevels.
+//
+// Fields added with With are recorded as per message attribs
+// of a golog sub-logger.
+func Core(logger *golog.Logger, convertLevel ConvertLevelFunc) zapcore.Core {
+	return &core{logger: logger, convertLevel: convertLevel}
+}
+
+// core implements zapcore.Core by routing to a golog.Logger.
+type core struct {
+	logger       *golog.Logger    // The underlying golog logger
+	convertLevel ConvertLevelFunc // Function to convert zap levels to golog levels
+	namespace    string           // Namespace opened by fields passed to With
+}
+
+// Enabled implements zapcore.LevelEnabler by checking
+// if the golog logger is active for the converted level.
+func (c *core) Enabled(level zapcore.Level) bool {
+	return c.logger.IsActive(context.Background(), c.convertLevel(level))
+}
+
+// With returns a child core with the fields
+// recorded as attribs of a golog sub-logger.
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	if len(fields) == 0 {
+		return c
+	}
+	enc := &messageEncoder{m: c.logger.With(), namespace: c.namespace}
+	for _, f := range fields {
+		enc.addField(f)
+	}
+	return &core{
+		logger:       enc.m.SubLogger(),
+		convertLevel: c.convertLevel,
+		namespace:    enc.namespace,
+	}
+}
+
+// Check implements zapcore.Core.
+func (c *core) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write implements zapcore.Core by logging the entry
+// and fields as golog message.
+func (c *core) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	logger := c.logger
+	if entry.LoggerName != "" {
+		if prefix := logger.Prefix(); prefix != "" {
+			logger = logger.WithPrefix(prefix + "." + entry.LoggerName)
+		} else {
+			logger = logger.WithPrefix(entry.LoggerName)
+		}
+	}
+	var (
+		ctx   = context.Background()
+		level = c.convertLevel(entry.Level)
+		m     *golog.Message
+	)
+	if entry.Caller.Defined && logger.SourceEnabled() {
+		source := golog.Source{Function: entry.Caller.Function, File: entry.Caller.File, Line: entry.Caller.Line}
+		m = logger.NewMessageWithSource(ctx, entry.Time, level, entry.Message, source)
+	} else {
+		m = logger.NewMessageAt(ctx, entry.Time, level, entry.Message)
+	}
+	if m == nil {
+		return nil
+	}
+	enc := &messageEncoder{m: m, namespace: c.namespace}
+	for _, f := range fields {
+		enc.addField(f)
+	}
+	if entry.Stack != "" {
+		m.Str(StacktraceKey, entry.Stack)
+	}
+	m.Log()
+	return nil
+}
+
+// Sync implements zapcore.Core by flushing the golog logger.
+func (c *core) Sync() error {
+	c.logger.Flush()
+	return nil
+}
@@ -0,0 +1,135 @@
+package gozap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/domonda/golog"
+)
+
+func TestConvertDefaultLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		l    zapcore.Level
+		want golog.Level
+	}{
+		{name: "Debug-1", l: zapcore.DebugLevel - 1, want: golog.DefaultLevels.Debug - 1},
+		{name: "Debug", l: zapcore.DebugLevel, want: golog.DefaultLevels.Debug},
+		{name: "Info", l: zapcore.InfoLevel, want: golog.DefaultLevels.Info},
+		{name: "Warn", l: zapcore.WarnLevel, want: golog.DefaultLevels.Warn},
+		{name: "Error", l: zapcore.ErrorLevel, want: golog.DefaultLevels.Error},
+		{name: "DPanic", l: zapcore.DPanicLevel, want: golog.DefaultLevels.Error + 1},
+		{name: "Panic", l: zapcore.PanicLevel, want: golog.DefaultLevels.Error + 2},
+		{name: "Fatal", l: zapcore.FatalLevel, want: golog.DefaultLevels.Fatal},
+		{name: "Fatal+1", l: zapcore.FatalLevel + 1, want: golog.DefaultLevels.Fatal + 1},
+		{name: "Invalid", l: zapcore.FatalLevel + 10, want: golog.LevelInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertDefaultLevels(tt.l))
+		})
+	}
+}
+
+func newTestLogger(buf *bytes.Buffer, filter golog.LevelFilter) *golog.Logger {
+	return golog.NewLogger(golog.NewConfig(&golog.DefaultLevels, filter, golog.NewJSONWriterConfig(buf, golog.NewDefaultFormat())))
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for line := range bytes.Lines(buf.Bytes()) {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(line, &record), string(line))
+		records = append(records, record)
+	}
+	return records
+}
+
+type testObject struct {
+	name  string
+	count int
+}
+
+func (o testObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", o.name)
+	enc.AddInt("count", o.count)
+	return nil
+}
+
+func TestCore(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zap.New(Core(newTestLogger(&buf, golog.DefaultLevels.Info.FilterOutBelow()), ConvertDefaultLevels))
+
+	logger.Debug("Filtered")
+	assert.Empty(t, buf.String())
+
+	logger.
+		Named("db").
+		With(zap.String("service", "api")).
+		Warn("Query failed",
+			zap.Error(errors.New("timeout")),
+			zap.Int("rows", 3),
+			zap.Bool("retry", true),
+			zap.Duration("took", 1500*time.Millisecond),
+			zap.Strings("tables", []string{"a", "b"}),
+			zap.Object("obj", testObject{name: "x", count: 2}),
+			zap.Namespace("ns"),
+			zap.Uint8("inner", 7),
+		)
+	require.NoError(t, logger.Sync())
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 1)
+	record := records[0]
+	assert.NotEmpty(t, record["time"])
+	delete(record, "time")
+	assert.Equal(t, map[string]any{
+		"level":    "WARN",
+		"message":  "db: Query failed",
+		"service":  "api",
+		"error":    "timeout",
+		"rows":     float64(3),
+		"retry":    true,
+		"took":     "1.5s",
+		"tables":   []any{"a", "b"},
+		"obj":      map[string]any{"name": "x", "count": float64(2)},
+		"ns.inner": float64(7),
+	}, record)
+}
+
+func TestCore_WithNamespace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zap.New(Core(newTestLogger(&buf, golog.AllLevelsActive), ConvertDefaultLevels)).
+		With(zap.Namespace("req"), zap.String("id", "1"))
+
+	logger.Info("Hello", zap.String("path", "/"))
+
+	records := decodeLines(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "1", records[0]["req.id"])
+	assert.Equal(t, "/", records[0]["req.path"])
+}
+
+func TestCore_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	gologLogger := newTestLogger(&buf, golog.AllLevelsActive).WithSource(0)
+	logger := zap.New(Core(gologLogger, ConvertDefaultLevels), zap.AddCaller())
+
+	logger.Info("Hello")
+
+	var record struct {
+		Source golog.Source `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "github.com/domonda/golog/gozap.TestCore_Caller", record.Source.Function)
+	assert.Contains(t, record.Source.File, "gozap/core_test.go")
+}
@@ -0,0 +1,149 @@
+package gozap
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/domonda/golog"
+)
+
+var _ zapcore.ObjectEncoder = new(messageEncoder)
+
+// messageEncoder implements zapcore.ObjectEncoder
+// by logging the added fields to a golog.Message.
+type messageEncoder struct {
+	m         *golog.Message
+	namespace string
+}
+
+// addField adds a zap field to the message.
+// Errors are logged as golog errors instead
+// of the strings zap would encode.
+func (e *messageEncoder) addField(f zapcore.Field) {
+	if f.Type == zapcore.ErrorType {
+		if err, ok := f.Interface.(error); ok {
+			e.m.Error(e.key(f.Key), err)
+			return
+		}
+	}
+	f.AddTo(e)
+}
+
+// key returns key prefixed with the namespace.
+func (e *messageEncoder) key(key string) string {
+	if e.namespace == "" {
+		return key
+	}
+	return e.namespace + "." + key
+}
+
+func (e *messageEncoder) AddArray(key string, marshaler zapcore.ArrayMarshaler) error {
+	// Let zap's map encoder convert the array to Go values
+	enc := zapcore.NewMapObjectEncoder()
+	err := enc.AddArray(key, marshaler)
+	e.m.Any(e.key(key), enc.Fields[key])
+	return err
+}
+
+func (e *messageEncoder) AddObject(key string, marshaler zapcore.ObjectMarshaler) error {
+	// Let zap's map encoder convert the object to a map
+	enc := zapcore.NewMapObjectEncoder()
+	err := marshaler.MarshalLogObject(enc)
+	e.m.Any(e.key(key), enc.Fields)
+	return err
+}
+
+func (e *messageEncoder) AddBinary(key string, value []byte) {
+	e.m.Bytes(e.key(key), value)
+}
+
+func (e *messageEncoder) AddByteString(key string, value []byte) {
+	e.m.StrBytes(e.key(key), value)
+}
+
+func (e *messageEncoder) AddBool(key string, value bool) {
+	e.m.Bool(e.key(key), value)
+}
+
+func (e *messageEncoder) AddComplex128(key string, value complex128) {
+	e.m.Str(e.key(key), fmt.Sprint(value))
+}
+
+func (e *messageEncoder) AddComplex64(key string, value complex64) {
+	e.m.Str(e.key(key), fmt.Sprint(value))
+}
+
+func (e *messageEncoder) AddDuration(key string, value time.Duration) {
+	e.m.Duration(e.key(key), value)
+}
+
+func (e *messageEncoder) AddFloat64(key string, value float64) {
+	e.m.Float(e.key(key), value)
+}
+
+func (e *messageEncoder) AddFloat32(key string, value float32) {
+	e.m.Float(e.key(key), float64(value))
+}
+
+func (e *messageEncoder) AddInt(key string, value int) {
+	e.m.Int(e.key(key), value)
+}
+
+func (e *messageEncoder) AddInt64(key string, value int64) {
+	e.m.Int64(e.key(key), value)
+}
+
+func (e *messageEncoder) AddInt32(key string, value int32) {
+	e.m.Int64(e.key(key), int64(value))
+}
+
+func (e *messageEncoder) AddInt16(key string, value int16) {
+	e.m.Int64(e.key(key), int64(value))
+}
+
+func (e *messageEncoder) AddInt8(key string, value int8) {
+	e.m.Int64(e.key(key), int64(value))
+}
+
+func (e *messageEncoder) AddString(key, value string) {
+	e.m.Str(e.key(key), value)
+}
+
+func (e *messageEncoder) AddTime(key string, value time.Time) {
+	e.m.Time(e.key(key), value)
+}
+
+func (e *messageEncoder) AddUint(key string, value uint) {
+	e.m.Uint(e.key(key), value)
+}
+
+func (e *messageEncoder) AddUint64(key string, value uint64) {
+	e.m.Uint64(e.key(key), value)
+}
+
+func (e *messageEncoder) AddUint32(key string, value uint32) {
+	e.m.Uint64(e.key(key), uint64(value))
+}
+
+func (e *messageEncoder) AddUint16(key string, value uint16) {
+	e.m.Uint64(e.key(key), uint64(value))
+}
+
+func (e *messageEncoder) AddUint8(key string, value uint8) {
+	e.m.Uint64(e.key(key), uint64(value))
+}
+
+func (e *messageEncoder) AddUintptr(key string, value uintptr) {
+	e.m.Uint64(e.key(key), uint64(value))
+}
+
+func (e *messageEncoder) AddReflected(key string, value any) error {
+	e.m.Any(e.key(key), value)
+	return nil
+}
+
+func (e *messageEncoder) OpenNamespace(key string) {
+	e.namespace = e.key(key)
+}
@@ -0,0 +1,27 @@
+module github.com/domonda/golog/gozerolog
+
+go 1.24.9
+
+replace github.com/domonda/golog => ..
+
+require github.com/domonda/golog v0.0.0-00010101000000-000000000000 // replaced
+
+require (
+	github.com/rs/zerolog v1.34.0
+	github.com/stretchr/testify v1.11.1
+)
+
+require (
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/domonda/go-encjson v1.0.0 // indirect
+	github.com/lucasb-eyer/go-colorful v1.4.0 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.21 // indirect
+	github.com/muesli/termenv v0.16.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/sys v0.41.0 // indirect
+	golang.org/x/term v0.40.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,326 @@
+/*
+Package gozerolog provides a zerolog.LevelWriter that routes
+the events of github.com/rs/zerolog to a golog.Logger.
+
+This way code using zerolog, for example vendored libraries,
+shares the writers and formats of golog.
+
+# Basic Usage
+
+	import (
+		"github.com/rs/zerolog"
+
+		"github.com/domonda/golog"
+		"github.com/domonda/golog/gozerolog"
+	)
+
+	gologLogger := golog.NewLogger(config)
+	logger := zerolog.New(gozerolog.NewWriter(gologLogger, gozerolog.ConvertDefaultLevels)).
+		With().Timestamp().Logger()
+	logger.Info().Str("key", "value").Msg("Hello from zerolog")
+
+# Conversion
+
+The Writer parses the JSON encoded events written by zerolog
+and logs the fields in their original order as golog attribs.
+The zerolog timestamp, level, and message fields become
+the timestamp, level, and text of the golog message.
+The error field is logged as golog error,
+strings, numbers, booleans, and null as typed attribs,
+and objects and arrays as JSON.
+
+If the golog.Logger captures source code locations
+(see golog.Logger.WithSource) then the caller field
+of events logged with zerolog's Caller() is logged as source location.
+
+Events that are not valid JSON are logged with the raw line as text.
+*/
+package gozerolog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/domonda/golog"
+)
+
+var _ zerolog.LevelWriter = new(Writer)
+
+// ConvertLevelFunc converts a zerolog.Level to a golog.Level.
+// Custom conversion functions can be provided to NewWriter
+// if different level mapping is required.
+type ConvertLevelFunc func(zerolog.Level) golog.Level
+
+// ConvertDefaultLevels converts zerolog log levels to golog levels:
+//   - zerolog.TraceLevel and below → golog.DefaultLevels.Trace and below
+//   - zerolog.DebugLevel → golog.DefaultLevels.Debug
+//   - zerolog.InfoLevel and zerolog.NoLevel → golog.DefaultLevels.Info
+//   - zerolog.WarnLevel → golog.DefaultLevels.Warn
+//   - zerolog.ErrorLevel → golog.DefaultLevels.Error
+//   - zerolog.FatalLevel and zerolog.PanicLevel → golog.DefaultLevels.Fatal
+//
+// zerolog.Disabled and levels outside the valid golog range
+// return golog.LevelInvalid.
+func ConvertDefaultLevels(l zerolog.Level) golog.Level {
+	var i int
+	switch l {
+	case zerolog.DebugLevel:
+		i = int(golog.DefaultLevels.Debug)
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		i = int(golog.DefaultLevels.Info)
+	case zerolog.WarnLevel:
+		i = int(golog.DefaultLevels.Warn)
+	case zerolog.ErrorLevel:
+		i = int(golog.DefaultLevels.Error)
+	case zerolog.FatalLevel, zerolog.PanicLevel:
+		i = int(golog.DefaultLevels.Fatal)
+	case zerolog.Disabled:
+		return golog.LevelInvalid
+	default:
+		if l > zerolog.Disabled {
+			return golog.LevelInvalid
+		}
+		i = int(golog.DefaultLevels.Trace) - int(zerolog.TraceLevel-l)
+	}
+	if i < int(golog.LevelMin) || i > int(golog.LevelMax) {
+		return golog.LevelInvalid
+	}
+	return golog.Level(i)
+}
+
+// Writer implements zerolog.LevelWriter by parsing
+// the JSON encoded zerolog events and logging them
+// as messages of a golog.Logger.
+//
+// The field names configured by the zerolog package variables
+// TimestampFieldName, LevelFieldName, MessageFieldName,
+// ErrorFieldName, and CallerFieldName,
+// and the time format TimeFieldFormat are respected.
+type Writer struct {
+	logger       *golog.Logger
+	convertLevel ConvertLevelFunc
+}
+
+// NewWriter returns a Writer logging to logger
+// using convertLevel to map zerolog levels to golog levels.
+// Use ConvertDefaultLevels for golog.DefaultLevels.
+func NewWriter(logger *golog.Logger, convertLevel ConvertLevelFunc) *Writer {
+	return &Writer{logger: logger, convertLevel: convertLevel}
+}
+
+// Write implements io.Writer.
+// The level is parsed from the level field of the event.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	level := zerolog.NoLevel
+	if fields, err := splitFields(p); err == nil {
+		for _, f := range fields {
+			var name string
+			if f.key == zerolog.LevelFieldName && json.Unmarshal(f.value, &name) == nil {
+				level, _ = zerolog.ParseLevel(name)
+				break
+			}
+		}
+	}
+	return w.WriteLevel(level, p)
+}
+
+// WriteLevel implements zerolog.LevelWriter.
+// It always returns len(p) and no error
+// because invalid events are logged as text.
+func (w *Writer) WriteLevel(zerologLevel zerolog.Level, p []byte) (n int, err error) {
+	ctx := context.Background()
+	level := w.convertLevel(zerologLevel)
+	if !w.logger.IsActive(ctx, level) {
+		return len(p), nil
+	}
+	fields, err := splitFields(p)
+	if err != nil {
+		w.logger.NewMessage(ctx, level, string(bytes.TrimSpace(p))).Log()
+		return len(p), nil
+	}
+
+	var (
+		timestamp time.Time
+		text      string
+		source    golog.Source
+	)
+	for _, f := range fields {
+		switch f.key {
+		case zerolog.TimestampFieldName:
+			timestamp = parseTime(f.value)
+		case zerolog.MessageFieldName:
+			_ = json.Unmarshal(f.value, &text)
+		case zerolog.CallerFieldName:
+			if w.logger.SourceEnabled() {
+				source = parseCaller(f.value)
+			}
+		}
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	m := w.logger.NewMessageWithSource(ctx, timestamp, level, text, source)
+	for _, f := range fields {
+		switch f.key {
+		case zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName:
+			continue
+		case zerolog.CallerFieldName:
+			if !source.IsZero() {
+				continue
+			}
+		case zerolog.ErrorFieldName:
+			var errStr string
+			if json.Unmarshal(f.value, &errStr) == nil {
+				m.Error(f.key, errors.New(errStr))
+				continue
+			}
+		}
+		writeValue(m, f.key, f.value)
+	}
+	m.Log()
+	return len(p), nil
+}
+
+type field struct {
+	key   string
+	value json.RawMessage
+}
+
+// splitFields returns the top level fields of the JSON object p
+// in their original order.
+func splitFields(p []byte) ([]field, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, errors.New("event is not a JSON object")
+	}
+	var fields []field
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var f field
+		f.key, _ = token.(string)
+		if err := dec.Decode(&f.value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON event")
+	}
+	return fields, nil
+}
+
+// writeValue logs a JSON value with the best matching golog type.
+func writeValue(m *golog.Message, key string, value json.RawMessage) {
+	switch value[0] {
+	case '"':
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			m.Str(key, s)
+			return
+		}
+	case 't':
+		m.Bool(key, true)
+		return
+	case 'f':
+		m.Bool(key, false)
+		return
+	case 'n':
+		m.Nil(key)
+		return
+	case '{', '[':
+		m.JSON(key, value)
+		return
+	default:
+		if i, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			m.Int64(key, i)
+			return
+		}
+		if u, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+			m.Uint64(key, u)
+			return
+		}
+		if f, err := strconv.ParseFloat(string(value), 64); err == nil {
+			m.Float(key, f)
+			return
+		}
+	}
+	m.JSON(key, value)
+}
+
+// parseTime parses a timestamp formatted by zerolog
+// using zerolog.TimeFieldFormat.
+// Returns a zero time if value could not be parsed.
+func parseTime(value json.RawMessage) time.Time {
+	if value[0] == '"' {
+		var s string
+		if json.Unmarshal(value, &s) != nil {
+			return time.Time{}
+		}
+		t, _ := time.Parse(zerolog.TimeFieldFormat, s)
+		return t
+	}
+	switch zerolog.TimeFieldFormat {
+	case zerolog.TimeFormatUnix:
+		if sec, err := strconv.ParseFloat(string(value), 64); err == nil {
+			return time.UnixMilli(int64(sec * 1000))
+		}
+	case zerolog.TimeFormatUnixMs:
+		if ms, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return time.UnixMilli(ms)
+		}
+	case zerolog.TimeFormatUnixMicro:
+		if us, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return time.UnixMicro(us)
+		}
+	case zerolog.TimeFormatUnixNano:
+		if ns, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return time.Unix(0, ns)
+		}
+	}
+	return time.Time{}
+}
+
+// parseCaller parses a caller field in the "file:line"
+// format of the default zerolog.CallerMarshalFunc.
+// Returns a zero Source if value could not be parsed.
+func parseCaller(value json.RawMessage) golog.Source {
+	var caller string
+	if json.Unmarshal(value, &caller) != nil {
+		return golog.Source{}
+	}
+	file, lineStr, ok := cutLast(caller, ":")
+	if !ok {
+		return golog.Source{}
+	}
+	line, err := strconv.Atoi(lineStr)
+	if err != nil {
+		return golog.Source{}
+	}
+	return golog.Source{File: file, Line: line}
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
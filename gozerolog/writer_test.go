@@ -0,0 +1,117 @@
+package gozerolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/golog"
+)
+
+func TestConvertDefaultLevels(t *testing.T) {
+	tests := []struct {
+		name string
+		l    zerolog.Level
+		want golog.Level
+	}{
+		{name: "Trace-1", l: zerolog.TraceLevel - 1, want: golog.DefaultLevels.Trace - 1},
+		{name: "Trace", l: zerolog.TraceLevel, want: golog.DefaultLevels.Trace},
+		{name: "Debug", l: zerolog.DebugLevel, want: golog.DefaultLevels.Debug},
+		{name: "Info", l: zerolog.InfoLevel, want: golog.DefaultLevels.Info},
+		{name: "Warn", l: zerolog.WarnLevel, want: golog.DefaultLevels.Warn},
+		{name: "Error", l: zerolog.ErrorLevel, want: golog.DefaultLevels.Error},
+		{name: "Fatal", l: zerolog.FatalLevel, want: golog.DefaultLevels.Fatal},
+		{name: "Panic", l: zerolog.PanicLevel, want: golog.DefaultLevels.Fatal},
+		{name: "NoLevel", l: zerolog.NoLevel, want: golog.DefaultLevels.Info},
+		{name: "Disabled", l: zerolog.Disabled, want: golog.LevelInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ConvertDefaultLevels(tt.l))
+		})
+	}
+}
+
+func newTestLogger(buf *bytes.Buffer, filter golog.LevelFilter) *golog.Logger {
+	return golog.NewLogger(golog.NewConfig(&golog.DefaultLevels, filter, golog.NewJSONWriterConfig(buf, golog.NewDefaultFormat())))
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(newTestLogger(&buf, golog.DefaultLevels.Info.FilterOutBelow()), ConvertDefaultLevels)
+	logger := zerolog.New(writer).With().Timestamp().Str("service", "api").Logger()
+
+	logger.Debug().Msg("Filtered")
+	assert.Empty(t, buf.String())
+
+	logger.Warn().
+		Err(errors.New("timeout")).
+		Int("rows", 3).
+		Uint64("big", 1<<63).
+		Float64("ratio", 0.5).
+		Bool("retry", true).
+		Strs("tables", []string{"a", "b"}).
+		Dict("obj", zerolog.Dict().Str("name", "x")).
+		Interface("nil", nil).
+		Msg("Query failed")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record), buf.String())
+	timestamp, err := time.Parse(golog.NewDefaultFormat().TimestampFormat, record["time"].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), timestamp, time.Minute)
+	delete(record, "time")
+	assert.Equal(t, map[string]any{
+		"level":   "WARN",
+		"message": "Query failed",
+		"service": "api",
+		"error":   "timeout",
+		"rows":    float64(3),
+		"big":     float64(1 << 63),
+		"ratio":   0.5,
+		"retry":   true,
+		"tables":  []any{"a", "b"},
+		"obj":     map[string]any{"name": "x"},
+		"nil":     nil,
+	}, record)
+
+	// Field order is preserved
+	assert.Regexp(t, `"service".*"error".*"rows".*"big".*"ratio".*"retry".*"tables".*"obj".*"nil"`, buf.String())
+}
+
+func TestWriter_Write(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(newTestLogger(&buf, golog.AllLevelsActive), ConvertDefaultLevels)
+
+	_, err := writer.Write([]byte(`{"level":"error","time":"2024-01-02T03:04:05Z","message":"Hello"}` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"time":"2024-01-02 03:04:05.000","level":"ERROR","message":"Hello"}`+"\n", buf.String())
+
+	buf.Reset()
+	_, err = writer.Write([]byte("not JSON\n"))
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"level":"INFO","message":"not JSON"`)
+}
+
+func TestWriter_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(newTestLogger(&buf, golog.AllLevelsActive).WithSource(0), ConvertDefaultLevels)
+	logger := zerolog.New(writer).With().Caller().Logger()
+
+	logger.Info().Msg("Hello")
+
+	var record struct {
+		Source golog.Source `json:"source"`
+		Caller string       `json:"caller"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Contains(t, record.Source.File, "gozerolog/writer_test.go")
+	assert.NotZero(t, record.Source.Line)
+	assert.Empty(t, record.Caller, "caller field replaced by source")
+}
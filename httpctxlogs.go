@@ -0,0 +1,361 @@
+package golog
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// HTTPCtxLogsFormat is the response format
+// used by HTTPMiddlewareRespondCtxLogsOnError.
+type HTTPCtxLogsFormat int
+
+const (
+	// HTTPCtxLogsPlaintext responds with the captured logs
+	// formatted by a TextWriter without colors followed by
+	// the original response body if it is text.
+	HTTPCtxLogsPlaintext HTTPCtxLogsFormat = iota
+
+	// HTTPCtxLogsJSON responds with a JSON object
+	// containing the status code, the captured logs
+	// as array of JSON log messages, and the original
+	// response body embedded as JSON value if it is
+	// valid JSON or else as string.
+	HTTPCtxLogsJSON
+)
+
+// HTTPCtxLogsOptions configures HTTPMiddlewareRespondCtxLogsOnError.
+// The zero value captures all logs of every request
+// and responds with plaintext for status codes 400 and above.
+type HTTPCtxLogsOptions struct {
+	// Format of the error response with the captured logs.
+	Format HTTPCtxLogsFormat
+
+	// Filter for the captured log messages.
+	// Nil captures all levels.
+	Filter LevelFilter
+
+	// Format for the captured log messages.
+	// Nil uses NewDefaultFormat.
+	LogFormat *Format
+
+	// MaxLogBytes limits the size of the captured log messages per request.
+	// If the limit is exceeded, then the oldest messages are dropped
+	// and the number of dropped messages is reported in the response.
+	// Zero or a negative value captures all messages without limit.
+	MaxLogBytes int
+
+	// MaxBodyBytes limits the size of the buffered body of an error response.
+	// Bytes written by the wrapped handler exceeding the limit are discarded
+	// and the truncation is reported in the response.
+	// Zero uses DefaultHTTPCtxLogsMaxBodyBytes,
+	// a negative value buffers the whole body without limit.
+	MaxBodyBytes int
+
+	// EnableHeader is the name of a request header that has to be set
+	// to enable capturing of logs for a request.
+	// If empty, logs are captured for every request.
+	// Use this to make log responses opt-in, for example in dev environments.
+	EnableHeader string
+
+	// EnableToken is the value the EnableHeader has to have
+	// to enable capturing of logs. It is compared in constant time.
+	// If empty, any non empty value of EnableHeader enables capturing.
+	EnableToken string
+
+	// IsError returns if a response with the passed status code
+	// should be replaced by a response with the captured logs.
+	// If nil, status codes 400 and above are treated as errors.
+	IsError func(statusCode int) bool
+}
+
+// DefaultHTTPCtxLogsMaxBodyBytes is the limit for the buffered
+// body of an error response used if HTTPCtxLogsOptions.MaxBodyBytes is zero.
+const DefaultHTTPCtxLogsMaxBodyBytes = 64 << 10
+
+func (o *HTTPCtxLogsOptions) maxBodyBytes() int {
+	if o.MaxBodyBytes == 0 {
+		return DefaultHTTPCtxLogsMaxBodyBytes
+	}
+	return o.MaxBodyBytes
+}
+
+func (o *HTTPCtxLogsOptions) isEnabled(request *http.Request) bool {
+	if o.EnableHeader == "" {
+		return true
+	}
+	value := request.Header.Get(o.EnableHeader)
+	if value == "" {
+		return false
+	}
+	if o.EnableToken == "" {
+		return true
+	}
+	return subtle.ConstantTimeCompare([]byte(value), []byte(o.EnableToken)) == 1
+}
+
+func (o *HTTPCtxLogsOptions) isError(statusCode int) bool {
+	if o.IsError == nil {
+		return statusCode >= 400
+	}
+	return o.IsError(statusCode)
+}
+
+// HTTPMiddlewareRespondCtxLogsOnError adds a WriterConfig to the request context
+// that collects all log messages that are created with the passed through context
+// and responds with the collected logs instead of the original response
+// if the status code from the wrapped handler is an error.
+//
+// In contrast to HTTPMiddlewareRespondPlaintextCtxLogsIfNotOK
+// successful responses are not buffered but passed through
+// as soon as the wrapped handler writes the header,
+// so streaming responses like server-sent events work
+// including flushing via http.Flusher or http.ResponseController.
+// Only the body of error responses is buffered
+// up to HTTPCtxLogsOptions.MaxBodyBytes.
+//
+// Pass nil options to use the defaults of the zero HTTPCtxLogsOptions value.
+// See also HTTPMiddlewareRespondCtxLogsOnErrorFunc.
+func HTTPMiddlewareRespondCtxLogsOnError(wrapped http.Handler, options *HTTPCtxLogsOptions) http.HandlerFunc {
+	if options == nil {
+		options = new(HTTPCtxLogsOptions)
+	}
+	return func(response http.ResponseWriter, request *http.Request) {
+		if !options.isEnabled(request) {
+			wrapped.ServeHTTP(response, request)
+			return
+		}
+
+		var (
+			logBuffer    = &ctxLogsBuffer{maxBytes: options.MaxLogBytes}
+			writerConfig WriterConfig
+		)
+		if options.Format == HTTPCtxLogsJSON {
+			writerConfig = NewJSONWriterConfig(logBuffer, options.LogFormat, options.Filter)
+		} else {
+			writerConfig = NewTextWriterConfig(logBuffer, options.LogFormat, NoColorizer, options.Filter)
+		}
+		logContext := ContextWithAdditionalWriterConfigs(request.Context(), writerConfig)
+		capture := &ctxLogsResponseWriter{ResponseWriter: response, options: options}
+
+		wrapped.ServeHTTP(capture, request.WithContext(logContext))
+
+		if !capture.wroteHeader {
+			capture.WriteHeader(http.StatusOK)
+		}
+		if capture.isError {
+			logs, dropped := logBuffer.Logs()
+			capture.respondLogs(logs, dropped)
+		}
+	}
+}
+
+// HTTPMiddlewareRespondCtxLogsOnErrorFunc returns HTTPMiddlewareRespondCtxLogsOnError
+// as middleware function compatible with github.com/gorilla/mux.MiddlewareFunc.
+func HTTPMiddlewareRespondCtxLogsOnErrorFunc(options *HTTPCtxLogsOptions) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return HTTPMiddlewareRespondCtxLogsOnError(next, options)
+	}
+}
+
+// ctxLogsResponseWriter passes through successful responses
+// and buffers error responses up to options.maxBodyBytes().
+type ctxLogsResponseWriter struct {
+	http.ResponseWriter
+	options       *HTTPCtxLogsOptions
+	wroteHeader   bool
+	isError       bool
+	statusCode    int
+	body          bytes.Buffer
+	bodyTruncated int // number of discarded body bytes
+}
+
+func (w *ctxLogsResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	// Informational responses like 103 Early Hints
+	// can be sent before the final status code
+	if statusCode >= 100 && statusCode < 200 && statusCode != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.wroteHeader = true
+	w.statusCode = statusCode
+	w.isError = w.options.isError(statusCode)
+	if !w.isError {
+		w.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func (w *ctxLogsResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.isError {
+		w.bufferBody(data)
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// bufferBody buffers data of an error response body
+// and discards the bytes exceeding options.maxBodyBytes().
+func (w *ctxLogsResponseWriter) bufferBody(data []byte) {
+	if maxBytes := w.options.maxBodyBytes(); maxBytes > 0 {
+		if free := max(maxBytes-w.body.Len(), 0); len(data) > free {
+			w.bodyTruncated += len(data) - free
+			data = data[:free]
+		}
+	}
+	w.body.Write(data)
+}
+
+// Flush implements http.Flusher.
+// Error responses are only written after the wrapped handler returned
+// so flushing is a no-op for them.
+func (w *ctxLogsResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.isError {
+		return
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap returns the wrapped http.ResponseWriter
+// for usage by http.ResponseController.
+func (w *ctxLogsResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *ctxLogsResponseWriter) respondLogs(logs []byte, dropped int) {
+	header := w.ResponseWriter.Header()
+	contentType := header.Get("Content-Type")
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+	header.Set("X-Content-Type-Options", "nosniff")
+
+	if w.options.Format == HTTPCtxLogsJSON {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+		w.ResponseWriter.WriteHeader(w.statusCode)
+		_, _ = w.ResponseWriter.Write(w.jsonResponse(logs, dropped, contentType))
+		return
+	}
+
+	header.Set("Content-Type", "text/plain; charset=utf-8")
+	w.ResponseWriter.WriteHeader(w.statusCode)
+	switch {
+	case dropped == 1:
+		_, _ = w.ResponseWriter.Write([]byte("(1 earlier log message dropped)\n"))
+	case dropped > 1:
+		_, _ = fmt.Fprintf(w.ResponseWriter, "(%d earlier log messages dropped)\n", dropped)
+	}
+	_, _ = w.ResponseWriter.Write(logs)
+	if w.body.Len() > 0 && isTextContentType(contentType) {
+		_, _ = w.ResponseWriter.Write([]byte("\n\n"))
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		if w.bodyTruncated > 0 {
+			_, _ = fmt.Fprintf(w.ResponseWriter, "\n(%d bytes of response body truncated)\n", w.bodyTruncated)
+		}
+	}
+}
+
+func (w *ctxLogsResponseWriter) jsonResponse(logs []byte, dropped int, contentType string) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"status":`)
+	b.WriteString(strconv.Itoa(w.statusCode))
+	b.WriteString(`,"statusText":`)
+	statusText, _ := json.Marshal(http.StatusText(w.statusCode))
+	b.Write(statusText)
+	b.WriteString(`,"logs":[`)
+	first := true
+	for line := range bytes.Lines(logs) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		b.Write(line)
+		first = false
+	}
+	b.WriteByte(']')
+	if dropped > 0 {
+		b.WriteString(`,"droppedLogs":`)
+		b.WriteString(strconv.Itoa(dropped))
+	}
+	if body := bytes.TrimSpace(w.body.Bytes()); len(body) > 0 {
+		b.WriteString(`,"body":`)
+		switch {
+		case strings.HasPrefix(contentType, "application/json") && json.Valid(body):
+			b.Write(body)
+		case isTextContentType(contentType):
+			str, _ := json.Marshal(string(body))
+			b.Write(str)
+		default:
+			b.WriteString(`null`)
+		}
+		if w.bodyTruncated > 0 {
+			b.WriteString(`,"bodyTruncatedBytes":`)
+			b.WriteString(strconv.Itoa(w.bodyTruncated))
+		}
+	}
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+func isTextContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "application/xml")
+}
+
+// ctxLogsBuffer collects the log messages of a request.
+// It is safe for concurrent use, because log messages
+// may be written from goroutines started by a request handler.
+// Every Write is expected to be a complete log message
+// so that the oldest messages can be dropped
+// if the total size exceeds maxBytes.
+type ctxLogsBuffer struct {
+	mutex    sync.Mutex
+	maxBytes int
+	messages [][]byte
+	size     int
+	dropped  int
+}
+
+func (b *ctxLogsBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.messages = append(b.messages, bytes.Clone(p))
+	b.size += len(p)
+	if b.maxBytes > 0 {
+		for b.size > b.maxBytes && len(b.messages) > 0 {
+			b.size -= len(b.messages[0])
+			b.messages[0] = nil
+			b.messages = b.messages[1:]
+			b.dropped++
+		}
+	}
+	return len(p), nil
+}
+
+// Logs returns a copy of the buffered log messages
+// and the number of dropped messages.
+func (b *ctxLogsBuffer) Logs() (logs []byte, dropped int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return bytes.Join(b.messages, nil), b.dropped
+}
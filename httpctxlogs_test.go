@@ -0,0 +1,177 @@
+package golog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMiddlewareRespondCtxLogsOnError(t *testing.T) {
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NopWriterConfig("nop")))
+
+	errorHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.ErrorCtx(r.Context(), "Something went wrong").Int("code", 42).Log()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"bad request"}`))
+	})
+
+	t.Run("passes through streaming OK response", func(t *testing.T) {
+		var flushedBeforeReturn bool
+		rr := httptest.NewRecorder()
+		handler := HTTPMiddlewareRespondCtxLogsOnError(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				log.InfoCtx(r.Context(), "Streaming").Log()
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = w.Write([]byte("data: first\n\n"))
+				require.NoError(t, http.NewResponseController(w).Flush())
+				flushedBeforeReturn = rr.Flushed && rr.Body.String() == "data: first\n\n"
+				_, _ = w.Write([]byte("data: second\n\n"))
+			}),
+			nil,
+		)
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+		assert.True(t, flushedBeforeReturn, "response not buffered")
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "data: first\n\ndata: second\n\n", rr.Body.String())
+	})
+
+	t.Run("responds plaintext logs on error", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(errorHandler, nil).
+			ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), "Something went wrong")
+		assert.Contains(t, rr.Body.String(), "code=42")
+		assert.Contains(t, rr.Body.String(), "\n\n"+`{"error":"bad request"}`)
+	})
+
+	t.Run("responds JSON logs on error", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(errorHandler, &HTTPCtxLogsOptions{Format: HTTPCtxLogsJSON}).
+			ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rr.Header().Get("Content-Type"))
+		var body struct {
+			Status     int              `json:"status"`
+			StatusText string           `json:"statusText"`
+			Logs       []map[string]any `json:"logs"`
+			Body       map[string]any   `json:"body"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusBadRequest, body.Status)
+		assert.Equal(t, "Bad Request", body.StatusText)
+		require.Len(t, body.Logs, 1)
+		assert.Equal(t, "Something went wrong", body.Logs[0]["message"])
+		assert.Equal(t, float64(42), body.Logs[0]["code"])
+		assert.Equal(t, map[string]any{"error": "bad request"}, body.Body)
+	})
+
+	t.Run("opt-in via header and token", func(t *testing.T) {
+		handler := HTTPMiddlewareRespondCtxLogsOnError(errorHandler, &HTTPCtxLogsOptions{
+			EnableHeader: "X-Debug-Logs",
+			EnableToken:  "secret",
+		})
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, `{"error":"bad request"}`, rr.Body.String(), "not enabled without header")
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("X-Debug-Logs", "wrong")
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, request)
+		assert.Equal(t, `{"error":"bad request"}`, rr.Body.String(), "not enabled with wrong token")
+
+		request.Header.Set("X-Debug-Logs", "secret")
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, request)
+		assert.Contains(t, rr.Body.String(), "Something went wrong")
+	})
+
+	t.Run("custom IsError", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(errorHandler, &HTTPCtxLogsOptions{
+			IsError: func(statusCode int) bool { return statusCode >= 500 },
+		}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, `{"error":"bad request"}`, rr.Body.String())
+	})
+
+	t.Run("MaxLogBytes drops oldest messages", func(t *testing.T) {
+		manyLogsHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i := range 10 {
+				log.InfoCtx(r.Context(), "Step").Int("i", i).Log()
+			}
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+		format := &Format{MessageKey: "message"}
+
+		rr := httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(manyLogsHandler, &HTTPCtxLogsOptions{
+			LogFormat:   format,
+			MaxLogBytes: 40,
+		}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, "(8 earlier log messages dropped)\n |INFO | Step i=8\n |INFO | Step i=9\n", rr.Body.String())
+
+		rr = httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(manyLogsHandler, &HTTPCtxLogsOptions{
+			Format:      HTTPCtxLogsJSON,
+			LogFormat:   format,
+			MaxLogBytes: 50,
+		}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, `{"status":500,"statusText":"Internal Server Error","logs":[{"message":"Step","i":8},{"message":"Step","i":9}],"droppedLogs":8}`+"\n", rr.Body.String())
+	})
+
+	t.Run("MaxBodyBytes truncates error body", func(t *testing.T) {
+		largeBodyHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusInternalServerError)
+			for range 3 {
+				n, err := w.Write([]byte("0123456789"))
+				assert.Equal(t, 10, n, "discarded bytes are reported as written")
+				assert.NoError(t, err)
+			}
+		})
+		format := &Format{MessageKey: "message"}
+
+		rr := httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(largeBodyHandler, &HTTPCtxLogsOptions{
+			LogFormat:    format,
+			MaxBodyBytes: 15,
+		}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, "\n\n012345678901234\n(15 bytes of response body truncated)\n", rr.Body.String())
+
+		rr = httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(largeBodyHandler, &HTTPCtxLogsOptions{
+			Format:       HTTPCtxLogsJSON,
+			LogFormat:    format,
+			MaxBodyBytes: 15,
+		}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, `{"status":500,"statusText":"Internal Server Error","logs":[],"body":"012345678901234","bodyTruncatedBytes":15}`+"\n", rr.Body.String())
+
+		rr = httptest.NewRecorder()
+		HTTPMiddlewareRespondCtxLogsOnError(largeBodyHandler, &HTTPCtxLogsOptions{
+			LogFormat:    format,
+			MaxBodyBytes: -1,
+		}).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, "\n\n012345678901234567890123456789", rr.Body.String())
+	})
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
-	"strings"
 )
 
 const HTTPNoHeaders = "HTTPNoHeaders"
 
+var (
+	requestUUIDKey AttribKey[[16]byte] = "requestID"
+	requestIDKey   AttribKey[string]   = "requestID"
+)
+
 // GetOrCreateRequestUUID gets a UUID from a http.Request or creates one.
 // The X-Request-ID or X-Correlation-ID HTTP request headers will be
 // parsed as UUID in the format "994d5800-afca-401f-9c2f-d9e3e106e9ef".
@@ -45,51 +49,54 @@ func GetOrCreateRequestID(request *http.Request) string {
 }
 
 // GetRequestUUIDFromContext returns a UUID that was added
-// to the context as UUID attribute with the key "requestID".
-// If the context has no requestID attribute
+// to the context as UUID attribute with the key "requestID"
+// or as string attribute that can be parsed as UUID.
+// If the context has no such requestID attribute
 // then false will be returned for ok.
 func GetRequestUUIDFromContext(ctx context.Context) (requestID [16]byte, ok bool) {
-	attrib, ok := AttribsFromContext(ctx).Get("requestID").(*UUID)
-	if !ok {
-		return [16]byte{}, false
-	}
-	return attrib.val, true
+	return requestUUIDKey.FromContext(ctx)
 }
 
 // GetRequestIDFromContext returns a string
-// that was added to the context as attribute with the key "requestID".
+// that was added to the context as string or UUID attribute
+// with the key "requestID". UUIDs are returned formatted as string,
+// attributes of other types formatted by their ValueString method.
 // If the context has no requestID attribute
 // then and empty string will be returned.
 func GetRequestIDFromContext(ctx context.Context) string {
-	requestID := AttribsFromContext(ctx).Get("requestID")
-	if requestID == nil {
-		return ""
+	attribs := AttribsFromContext(ctx)
+	if requestID, ok := requestIDKey.Value(attribs); ok {
+		return requestID
+	}
+	if attrib := attribs.Get(string(requestIDKey)); attrib != nil {
+		return attrib.ValueString()
 	}
-	return requestID.ValueString()
+	return ""
 }
 
 // GetOrCreateRequestUUIDFromContext returns a UUID that was added
 // to the context as UUID attribute with the key "requestID"
-// If the context has no requestID attribute
+// or as string attribute that can be parsed as UUID.
+// If the context has no such requestID attribute
 // then a new random v4 UUID will be returned.
 func GetOrCreateRequestUUIDFromContext(ctx context.Context) [16]byte {
-	requestID, ok := AttribsFromContext(ctx).Get("requestID").(*UUID)
+	requestID, ok := requestUUIDKey.FromContext(ctx)
 	if !ok {
 		return UUIDv4()
 	}
-	return requestID.val
+	return requestID
 }
 
 // ContextWithRequestUUID adds the passed requestID as UUID
 // attribute with the key "requestID" to the context.
 func ContextWithRequestUUID(ctx context.Context, requestID [16]byte) context.Context {
-	return ContextWithAttribs(ctx, NewUUID("requestID", requestID))
+	return requestUUIDKey.ContextWith(ctx, requestID)
 }
 
 // ContextWithRequestID adds the passed requestID as string
 // attribute with the key "requestID" to the context.
 func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
-	return ContextWithAttribs(ctx, NewString("requestID", requestID))
+	return requestIDKey.ContextWith(ctx, requestID)
 }
 
 // HTTPMiddlewareHandler returns a HTTP middleware handler that passes through a UUID requestID.
@@ -110,7 +117,7 @@ func HTTPMiddlewareHandler(next http.Handler, logger *Logger, level Level, messa
 			requestID := GetOrCreateRequestUUID(request)
 			response.Header().Set("X-Request-ID", FormatUUID(requestID))
 
-			requestWithID := RequestWithAttribs(request, NewUUID("requestID", requestID))
+			requestWithID := RequestWithAttribs(request, requestUUIDKey.Attrib(requestID))
 
 			logger.NewMessage(request.Context(), level, message).
 				Request(requestWithID, onlyHeaders...).
@@ -146,6 +153,8 @@ func HTTPMiddlewareFunc(logger *Logger, level Level, message string, onlyHeaders
 // if the response status code from the wrapped handler is not 200 OK.
 // In case of a status code 200 OK response from the wrapped handler
 // the original response will be passed through.
+// The complete response is buffered, use HTTPMiddlewareRespondCtxLogsOnError
+// for streaming responses or JSON output.
 func HTTPMiddlewareRespondPlaintextCtxLogsIfNotOK(wrapped http.Handler, filter ...LevelFilter) http.HandlerFunc {
 	return func(response http.ResponseWriter, request *http.Request) {
 		var (
@@ -166,8 +175,7 @@ func HTTPMiddlewareRespondPlaintextCtxLogsIfNotOK(wrapped http.Handler, filter .
 			_, _ = response.Write(logBuffer.Bytes())
 
 			// Also respond with the recorded response body if it is text
-			ct := responseRecorder.Header().Get("Content-Type")
-			if strings.HasPrefix(ct, "text/") || strings.HasPrefix(ct, "application/json") || strings.HasPrefix(ct, "application/xml") {
+			if isTextContentType(responseRecorder.Header().Get("Content-Type")) {
 				_, _ = response.Write([]byte("\n\n"))
 				_, _ = response.Write(responseRecorder.Body.Bytes())
 				return
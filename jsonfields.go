@@ -0,0 +1,94 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// jsonField is a top level field of a JSON object
+// with the raw JSON value.
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// parseJSONFields returns the top level fields of the JSON object
+// in data in their original order.
+func parseJSONFields(data []byte) ([]jsonField, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, errors.New("not a JSON object")
+	}
+	var fields []jsonField
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var f jsonField
+		f.key, _ = token.(string)
+		if err := dec.Decode(&f.value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON object")
+	}
+	return fields, nil
+}
+
+// stringValue returns the value of the field
+// if it is a JSON string.
+func (f *jsonField) stringValue() (string, bool) {
+	var s string
+	if len(f.value) == 0 || f.value[0] != '"' || json.Unmarshal(f.value, &s) != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// jsonValue logs a raw JSON value with the best matching type:
+// strings, booleans, integers, floats, and null as typed values,
+// objects and arrays as JSON.
+func (m *Message) jsonValue(key string, value json.RawMessage) *Message {
+	if len(value) == 0 {
+		return m.Nil(key)
+	}
+	switch value[0] {
+	case '"':
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			return m.Str(key, s)
+		}
+	case 't':
+		return m.Bool(key, true)
+	case 'f':
+		return m.Bool(key, false)
+	case 'n':
+		return m.Nil(key)
+	case '{', '[':
+		return m.JSON(key, value)
+	default:
+		if i, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return m.Int64(key, i)
+		}
+		if u, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+			return m.Uint64(key, u)
+		}
+		if f, err := strconv.ParseFloat(string(value), 64); err == nil {
+			return m.Float(key, f)
+		}
+	}
+	return m.JSON(key, value)
+}
@@ -0,0 +1,363 @@
+package golog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONRecord is a log message parsed by JSONReader.
+type JSONRecord struct {
+	Timestamp time.Time
+	Level     Level
+	Prefix    string
+	Text      string
+	Attribs   Attribs
+}
+
+// Log writes the record to all writers of config
+// if the record's level is active for config.
+func (r *JSONRecord) Log(ctx context.Context, config Config) {
+	if !config.IsActive(ctx, r.Level) {
+		return
+	}
+	writeRecordedMessage(ctx, config, r.Timestamp, r.Level, r.Prefix, r.Text, r.Attribs)
+}
+
+// JSONReader parses JSON log lines as written by JSONWriter
+// or other structured loggers back into JSONRecords.
+//
+// The keys of the passed Format are used to find
+// the timestamp, level, and message of a line.
+// If a line has no value for a key of the Format,
+// then the common alternatives
+// "time", "ts", "timestamp", "@timestamp" for the timestamp,
+// "level", "lvl", "severity" for the level,
+// and "message", "msg" for the message are used.
+//
+// Timestamps are parsed with Format.TimestampFormat
+// or else with ParseTimestamp, numbers as Unix seconds.
+// Levels are looked up with Levels.LevelOfName and common
+// case insensitive aliases like "warning" or "err",
+// unknown levels are returned as Levels.Info.
+//
+// All other values are returned as typed Attribs:
+// null as Nil, booleans as Bool, integers as Int or Uint,
+// other numbers as Float, strings in UUID format as UUID,
+// strings in the Format.TimeFormat as Time,
+// the "error" key as Error, all other strings as String,
+// arrays of those scalar types as the corresponding slice attribs,
+// and objects and all other arrays as JSON.
+type JSONReader struct {
+	reader *bufio.Reader
+	levels *Levels
+	format *Format
+	line   int
+
+	// PrefixSep splits a prefix from the message text
+	// at the first occurence of the separator
+	// if the text before it contains no whitespace.
+	// Set it to ": " for messages written by JSONWriter
+	// with the default Format.PrefixFmt.
+	// An empty PrefixSep disables splitting.
+	PrefixSep string
+}
+
+// NewJSONReader returns a JSONReader parsing the lines of reader
+// using levels and format. If format is nil then NewDefaultFormat is used.
+func NewJSONReader(reader io.Reader, levels *Levels, format *Format) *JSONReader {
+	if levels == nil {
+		panic("golog.JSONReader needs Levels")
+	}
+	if format == nil {
+		format = NewDefaultFormat()
+	}
+	return &JSONReader{
+		reader: bufio.NewReader(reader),
+		levels: levels,
+		format: format,
+	}
+}
+
+// Read parses the next non empty line.
+// It returns io.EOF if there are no more lines.
+// An invalid line returns an error with the line number,
+// subsequent calls continue with the next line.
+func (r *JSONReader) Read() (*JSONRecord, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) == 0 && err != nil {
+			return nil, err
+		}
+		r.line++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		record, parseErr := r.ParseLine(line)
+		if parseErr != nil {
+			return nil, fmt.Errorf("golog.JSONReader line %d: %w", r.line, parseErr)
+		}
+		return record, nil
+	}
+}
+
+// Replay writes all records read from the reader
+// to config until the end of the reader.
+// It returns the number of read records
+// and the first error other than io.EOF.
+func (r *JSONReader) Replay(ctx context.Context, config Config) (n int, err error) {
+	for {
+		record, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+		record.Log(ctx, config)
+		n++
+	}
+}
+
+// ParseLine parses a single JSON log line.
+func (r *JSONReader) ParseLine(line []byte) (*JSONRecord, error) {
+	fields, err := parseJSONFields(line)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		record     = &JSONRecord{Level: r.levels.Info}
+		timestamp  = r.findField(fields, r.format.TimestampKey, "time", "ts", "timestamp", "@timestamp")
+		level      = r.findField(fields, r.format.LevelKey, "level", "lvl", "severity")
+		message    = r.findField(fields, r.format.MessageKey, "message", "msg")
+		timeFormat = r.format.TimeFormat
+	)
+	if timeFormat == "" {
+		timeFormat = DefaultTimeFormat
+	}
+	for i := range fields {
+		f := &fields[i]
+		switch i {
+		case timestamp:
+			record.Timestamp = r.parseTimestamp(f.value)
+		case level:
+			if name, ok := f.stringValue(); ok {
+				record.Level = r.parseLevel(name)
+			}
+		case message:
+			record.Text, _ = f.stringValue()
+			if r.PrefixSep != "" {
+				if prefix, text, found := strings.Cut(record.Text, r.PrefixSep); found && prefix != "" && !strings.ContainsAny(prefix, " \t\n") {
+					record.Prefix, record.Text = prefix, text
+				}
+			}
+		default:
+			record.Attribs.Add(parseJSONAttrib(f.key, f.value, timeFormat))
+		}
+	}
+	return record, nil
+}
+
+// findField returns the index of the first field with one of the keys
+// or -1 if there is none. Empty keys are ignored.
+func (r *JSONReader) findField(fields []jsonField, keys ...string) int {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		for i := range fields {
+			if fields[i].key == key {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (r *JSONReader) parseTimestamp(value json.RawMessage) time.Time {
+	var s string
+	if json.Unmarshal(value, &s) != nil {
+		if sec, err := strconv.ParseFloat(string(value), 64); err == nil {
+			return time.UnixMicro(int64(sec * 1e6))
+		}
+		return time.Time{}
+	}
+	if r.format.TimestampFormat != "" {
+		if t, err := time.Parse(r.format.TimestampFormat, s); err == nil {
+			return t
+		}
+	}
+	if t, err := ParseTimestamp(s); err == nil {
+		return t.Time
+	}
+	return time.Time{}
+}
+
+func (r *JSONReader) parseLevel(name string) Level {
+	if level := r.levels.LevelOfName(name); level != LevelInvalid {
+		return level
+	}
+	if level, ok := levelOfNameOrAlias(r.levels, strings.TrimSpace(name)); ok {
+		return level
+	}
+	return r.levels.Info
+}
+
+// parseJSONAttrib returns value as the best matching typed Attrib.
+func parseJSONAttrib(key string, value json.RawMessage, timeFormat string) Attrib {
+	switch value[0] {
+	case '{':
+		return NewJSON(key, value)
+	case '[':
+		if attrib := parseJSONSliceAttrib(key, value, timeFormat); attrib != nil {
+			return attrib
+		}
+		return NewJSON(key, value)
+	}
+	switch val := parseJSONScalar(key, value, timeFormat).(type) {
+	case nil:
+		return NewNil(key)
+	case bool:
+		return NewBool(key, val)
+	case int64:
+		return NewInt(key, val)
+	case uint64:
+		return NewUint(key, val)
+	case float64:
+		return NewFloat(key, val)
+	case [16]byte:
+		return NewUUID(key, val)
+	case time.Time:
+		return NewTime(key, val)
+	case error:
+		return NewError(key, val)
+	case string:
+		return NewString(key, val)
+	default:
+		return NewJSON(key, value)
+	}
+}
+
+// parseJSONScalar returns a scalar JSON value as Go value
+// of the types handled by parseJSONAttrib
+// or json.RawMessage if the value is not a scalar.
+func parseJSONScalar(key string, value json.RawMessage, timeFormat string) any {
+	switch value[0] {
+	case 'n':
+		return nil
+	case 't':
+		return true
+	case 'f':
+		return false
+	case '"':
+		var s string
+		if json.Unmarshal(value, &s) != nil {
+			return value
+		}
+		if key == "error" {
+			return errors.New(s)
+		}
+		if len(s) == 36 {
+			if id, err := ParseUUID(s); err == nil {
+				return id
+			}
+		}
+		if t, err := time.Parse(timeFormat, s); err == nil {
+			return t
+		}
+		return s
+	case '{', '[':
+		return value
+	default:
+		if i, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(string(value), 10, 64); err == nil {
+			return u
+		}
+		if f, err := strconv.ParseFloat(string(value), 64); err == nil {
+			return f
+		}
+		return value
+	}
+}
+
+// parseJSONSliceAttrib returns a slice attrib for a JSON array
+// with elements of the same scalar type
+// or nil if the array is empty or has mixed or non scalar elements.
+// Arrays of integers and floats are returned as Floats.
+func parseJSONSliceAttrib(key string, value json.RawMessage, timeFormat string) Attrib {
+	var elems []json.RawMessage
+	if json.Unmarshal(value, &elems) != nil || len(elems) == 0 {
+		return nil
+	}
+	vals := make([]any, len(elems))
+	for i, elem := range elems {
+		vals[i] = parseJSONScalar(key, elem, timeFormat)
+	}
+	switch vals[0].(type) {
+	case bool:
+		if s, ok := sliceOf[bool](vals); ok {
+			return NewBools(key, s)
+		}
+	case int64, uint64, float64:
+		if s, ok := sliceOf[int64](vals); ok {
+			return NewInts(key, s)
+		}
+		if s, ok := sliceOf[uint64](vals); ok {
+			return NewUints(key, s)
+		}
+		floats := make([]float64, len(vals))
+		for i, val := range vals {
+			switch x := val.(type) {
+			case int64:
+				floats[i] = float64(x)
+			case uint64:
+				floats[i] = float64(x)
+			case float64:
+				floats[i] = x
+			default:
+				return nil
+			}
+		}
+		return NewFloats(key, floats)
+	case [16]byte:
+		if s, ok := sliceOf[[16]byte](vals); ok {
+			return NewUUIDs(key, s)
+		}
+	case time.Time:
+		if s, ok := sliceOf[time.Time](vals); ok {
+			return NewTimes(key, s)
+		}
+	case error:
+		if s, ok := sliceOf[error](vals); ok {
+			return NewErrors(key, s)
+		}
+	case string:
+		if s, ok := sliceOf[string](vals); ok {
+			return NewStrings(key, s)
+		}
+	}
+	return nil
+}
+
+// sliceOf returns vals as []T if all elements have the type T.
+func sliceOf[T any](vals []any) ([]T, bool) {
+	s := make([]T, len(vals))
+	for i, val := range vals {
+		v, ok := val.(T)
+		if !ok {
+			return nil, false
+		}
+		s[i] = v
+	}
+	return s, true
+}
@@ -0,0 +1,122 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleJSONReader() {
+	archived := strings.NewReader(`{"time":"2024-01-02 03:04:05.000","level":"WARN","message":"db: Slow query","took_ms":1500,"tables":["a","b"]}
+{"ts":1704164645.5,"lvl":"error","msg":"Generic logger","requestID":"994d5800-afca-401f-9c2f-d9e3e106e9ef"}
+`)
+	reader := NewJSONReader(archived, &DefaultLevels, nil)
+	reader.PrefixSep = ": "
+
+	format := NewDefaultFormat()
+	format.Location = time.UTC
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(os.Stdout, format, NoColorizer))
+
+	_, err := reader.Replay(context.Background(), config)
+	if err != nil {
+		panic(err)
+	}
+
+	// Output:
+	// 2024-01-02 03:04:05.000 |WARN | db: Slow query took_ms=1500 tables=["a","b"]
+	// 2024-01-02 03:04:05.500 |ERROR| Generic logger requestID=994d5800-afca-401f-9c2f-d9e3e106e9ef
+}
+
+func TestJSONReader_roundtrip(t *testing.T) {
+	format := NewDefaultFormat()
+	var original bytes.Buffer
+	log := NewLoggerWithPrefix(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&original, format)), "pkg")
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	id := MustParseUUID("994d5800-afca-401f-9c2f-d9e3e106e9ef")
+	log.NewMessageAt(t.Context(), at, DefaultLevels.Error, "All types").
+		Nil("nil").
+		Bool("bool", true).
+		Int("int", -1).
+		Uint64("uint", 1<<63).
+		Float("float", 1.5).
+		Str("str", "x").
+		Err(errors.New("failed")).
+		Time("time", at).
+		UUID("uuid", id).
+		JSON("json", []byte(`{"a":1}`)).
+		Ints("ints", []int{1, 2}).
+		Floats("floats", []float64{1, 2.5}).
+		Strs("strs", []string{"a"}).
+		Bools("bools", []bool{true}).
+		UUIDs("uuids", [][16]byte{id}).
+		Log()
+	log.NewMessageAt(t.Context(), at, DefaultLevels.Debug, "Second").Log()
+
+	reader := NewJSONReader(bytes.NewReader(original.Bytes()), &DefaultLevels, format)
+	reader.PrefixSep = ": "
+
+	record, err := reader.Read()
+	require.NoError(t, err)
+	assert.True(t, at.Equal(record.Timestamp), "timestamp")
+	assert.Equal(t, DefaultLevels.Error, record.Level)
+	assert.Equal(t, "pkg", record.Prefix)
+	assert.Equal(t, "All types", record.Text)
+	assert.Equal(t, NewNil("nil"), record.Attribs.Get("nil"))
+	assert.Equal(t, NewInt("int", -1), record.Attribs.Get("int"))
+	assert.Equal(t, NewUint("uint", 1<<63), record.Attribs.Get("uint"))
+	assert.Equal(t, NewFloat("float", 1.5), record.Attribs.Get("float"))
+	assert.Equal(t, NewString("str", "x"), record.Attribs.Get("str"))
+	assert.Equal(t, "failed", record.Attribs.Get("error").Value().(error).Error())
+	assert.True(t, at.Equal(record.Attribs.Get("time").(*Time).Value().(time.Time)), "time attrib")
+	assert.Equal(t, NewUUID("uuid", id), record.Attribs.Get("uuid"))
+	assert.IsType(t, &JSON{}, record.Attribs.Get("json"))
+	assert.Equal(t, NewInts("ints", []int64{1, 2}), record.Attribs.Get("ints"))
+	assert.Equal(t, NewFloats("floats", []float64{1, 2.5}), record.Attribs.Get("floats"))
+	assert.Equal(t, NewStrings("strs", []string{"a"}), record.Attribs.Get("strs"))
+	assert.Equal(t, NewBools("bools", []bool{true}), record.Attribs.Get("bools"))
+	assert.Equal(t, NewUUIDs("uuids", [][16]byte{id}), record.Attribs.Get("uuids"))
+
+	// Replaying through the same format reproduces the original output
+	reader = NewJSONReader(bytes.NewReader(original.Bytes()), &DefaultLevels, format)
+	reader.PrefixSep = ": "
+	var replayed bytes.Buffer
+	n, err := reader.Replay(t.Context(), NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&replayed, format)))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, original.String(), replayed.String())
+}
+
+func TestJSONReader_Read(t *testing.T) {
+	input := "\n" +
+		`{"level":"WARNING","message":"Alias"}` + "\n" +
+		"not JSON\n" +
+		`{"severity":"unknown","msg":"Unknown level","prefix":"kept"}`
+	reader := NewJSONReader(strings.NewReader(input), &DefaultLevels, nil)
+
+	record, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLevels.Warn, record.Level)
+	assert.Equal(t, "Alias", record.Text)
+	assert.True(t, record.Timestamp.IsZero())
+
+	_, err = reader.Read()
+	require.ErrorContains(t, err, "line 3")
+
+	record, err = reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLevels.Info, record.Level)
+	assert.Equal(t, "Unknown level", record.Text)
+	assert.Equal(t, NewString("prefix", "kept"), record.Attribs.Get("prefix"))
+
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -10,8 +10,11 @@ import (
 )
 
 var (
-	_ Writer       = new(JSONWriter)
-	_ WriterConfig = new(JSONWriterConfig)
+	_ Writer            = new(JSONWriter)
+	_ ErrorChainWriter  = new(JSONWriter)
+	_ SourceWriter      = new(JSONWriter)
+	_ CommitErrorWriter = new(JSONWriter)
+	_ WriterConfig      = new(JSONWriterConfig)
 )
 
 type JSONWriterConfig struct {
@@ -34,6 +37,12 @@ func NewJSONWriterConfig(writer io.Writer, format *Format, filters ...LevelFilte
 	}
 }
 
+// IsActive implements the LevelDecider interface
+// by returning if the filter of the config passes the level.
+func (c *JSONWriterConfig) IsActive(ctx context.Context, level Level) bool {
+	return c.filter.IsActive(ctx, level)
+}
+
 func (c *JSONWriterConfig) WriterForNewMessage(ctx context.Context, level Level) Writer {
 	if c.filter.IsInactive(ctx, level) {
 		return nil
@@ -43,7 +52,7 @@ func (c *JSONWriterConfig) WriterForNewMessage(ctx context.Context, level Level)
 	if w.buf == nil {
 		w.buf = make([]byte, 0, 1024)
 	}
-	return w
+	return LimitWriter(w, &c.format.Limits)
 }
 
 func (c *JSONWriterConfig) FlushUnderlying() {
@@ -88,11 +97,19 @@ func (w *JSONWriter) BeginMessage(config Config, timestamp time.Time, level Leve
 }
 
 func (w *JSONWriter) CommitMessage() {
-	// Flush f.buf
+	if err := w.CommitMessageError(); err != nil && ErrorHandler != nil {
+		ErrorHandler(err)
+	}
+}
+
+// CommitMessageError commits the message like CommitMessage
+// but returns the error of writing it instead of passing it to ErrorHandler.
+func (w *JSONWriter) CommitMessageError() (err error) {
+	// Flush w.buf
 	if len(w.buf) > 0 {
-		_, err := w.config.writer.Write(append(w.buf, '}', '\n'))
-		if err != nil && ErrorHandler != nil {
-			ErrorHandler(fmt.Errorf("golog.JSONWriter error: %w", err))
+		_, err = w.config.writer.Write(append(w.buf, '}', '\n'))
+		if err != nil {
+			err = fmt.Errorf("golog.JSONWriter error: %w", err)
 		}
 	}
 
@@ -100,12 +117,18 @@ func (w *JSONWriter) CommitMessage() {
 	w.config = nil
 	w.buf = w.buf[:0]
 	jsonWriterPool.PutBack(w)
+	return err
 }
 
 func (w *JSONWriter) String() string {
 	return string(w.buf)
 }
 
+// bufferLen implements bufferWriter
+func (w *JSONWriter) bufferLen() int {
+	return len(w.buf)
+}
+
 func (w *JSONWriter) WriteKey(key string) {
 	w.buf = encjson.AppendKey(w.buf, key)
 }
@@ -151,6 +174,33 @@ func (w *JSONWriter) WriteError(val error) {
 	w.buf = encjson.AppendString(w.buf, val.Error())
 }
 
+func (w *JSONWriter) WriteErrorChain(chain *ErrorChain) {
+	w.buf = encjson.AppendObjectStart(w.buf)
+	w.buf = encjson.AppendKey(w.buf, "message")
+	w.buf = encjson.AppendString(w.buf, chain.Message)
+	w.buf = encjson.AppendKey(w.buf, "type")
+	w.buf = encjson.AppendString(w.buf, chain.Type)
+	if len(chain.Attribs) > 0 {
+		w.buf = encjson.AppendKey(w.buf, "attribs")
+		w.buf = encjson.AppendObjectStart(w.buf)
+		writeErrorChainAttribs(w, chain.Attribs)
+		w.buf = encjson.AppendObjectEnd(w.buf)
+	}
+	if len(chain.Causes) > 0 {
+		w.buf = encjson.AppendKey(w.buf, "causes")
+		w.buf = encjson.AppendArrayStart(w.buf)
+		for _, cause := range chain.Causes {
+			w.WriteErrorChain(cause)
+		}
+		w.buf = encjson.AppendArrayEnd(w.buf)
+	}
+	w.buf = encjson.AppendObjectEnd(w.buf)
+}
+
+func (w *JSONWriter) WriteSource(source Source) {
+	w.buf = source.AppendJSON(w.buf)
+}
+
 func (w *JSONWriter) WriteTime(val time.Time) {
 	format := w.config.format.TimeFormat
 	if format == "" {
@@ -162,6 +212,31 @@ func (w *JSONWriter) WriteTime(val time.Time) {
 	w.buf = encjson.AppendTime(w.buf, val, format)
 }
 
+func (w *JSONWriter) WriteDuration(val time.Duration) {
+	format := w.config.format.DurationFormat
+	// Appending empty raw JSON adds a comma separator if needed
+	w.buf = encjson.AppendJSON(w.buf, nil)
+	if format.IsNumber() {
+		w.buf = format.AppendDuration(w.buf, val)
+		return
+	}
+	w.buf = append(w.buf, '"')
+	w.buf = format.AppendDuration(w.buf, val)
+	w.buf = append(w.buf, '"')
+}
+
+func (w *JSONWriter) anyJSONConfig(config *anyJSONConfig) {
+	config.format = w.config.format
+}
+
+func (w *JSONWriter) writeAnyDuration(val time.Duration) {
+	if w.config.format.DurationFormat == "" {
+		w.WriteInt(int64(val))
+		return
+	}
+	w.WriteDuration(val)
+}
+
 func (w *JSONWriter) WriteUUID(val [16]byte) {
 	w.buf = encjson.AppendUUID(w.buf, val)
 }
@@ -0,0 +1,65 @@
+package golog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Lazy(t *testing.T) {
+	var textBuf, jsonBuf bytes.Buffer
+	format := &Format{LevelKey: "level"}
+	log := NewLogger(NewConfig(&DefaultLevels, DefaultLevels.Info.FilterOutBelow(),
+		NewTextWriterConfig(&textBuf, format, NoColorizer),
+		NewJSONWriterConfig(&jsonBuf, format),
+	))
+
+	calls := 0
+	compute := func() any {
+		calls++
+		return []int{1, 2}
+	}
+
+	log.Debug("Filtered").Lazy("val", compute).Log()
+	assert.Equal(t, 0, calls, "not called for inactive message")
+	assert.Empty(t, textBuf.String())
+
+	log.Info("Msg").Lazy("val", compute).Lazy("nil", nil).Log()
+	assert.Equal(t, 1, calls, "called once for multiple writers")
+	assert.Equal(t, " |INFO | Msg val=[1,2] nil=nil\n", textBuf.String())
+	assert.Equal(t, `{"level":"INFO","val":[1,2],"nil":null}`+"\n", jsonBuf.String())
+}
+
+func TestLogger_With_Lazy(t *testing.T) {
+	var jsonBuf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, DefaultLevels.Info.FilterOutBelow(),
+		NewJSONWriterConfig(&jsonBuf, &Format{}),
+	))
+
+	calls := 0
+	subLog := log.With().
+		Lazy("val", func() any {
+			calls++
+			return "computed"
+		}).
+		SubLogger()
+
+	lazy, ok := subLog.Attribs().Get("val").(*Lazy)
+	require.True(t, ok, "recorded as Lazy attrib")
+	assert.Equal(t, "val", lazy.Key())
+
+	subLog.Debug("Filtered").Log()
+	assert.Equal(t, 0, calls, "not called for inactive message")
+
+	subLog.Info("First").Log()
+	subLog.Info("Second").Log()
+	assert.Equal(t, 1, calls, "called once for all messages")
+	assert.Equal(t, `{"val":"computed"}`+"\n"+`{"val":"computed"}`+"\n", jsonBuf.String())
+
+	// Clones share the computed value
+	assert.Equal(t, "computed", lazy.Clone().Value())
+	assert.Equal(t, `"val":"computed"`, string(lazy.AppendJSON(nil)))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,245 @@
+package golog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// LevelDetectingWriter writes unstructured messages to a Logger
+// with the level detected from common prefixes of the message text.
+// It can be used as a shim/wrapper for the standard log package
+// and third party packages that need an io.Writer,
+// a standard log.Logger, or an interface implementation with a Printf method.
+//
+// The following markers at the beginning of the text are detected,
+// names are matched case insensitive and removed from the logged text:
+//   - "[ERROR] text", "[WARN] text", ...
+//   - "ERROR: text", "warning: text", ...
+//   - "level=error text" or "lvl=error" anywhere as logfmt token
+//
+// Level names are the names of the logger's Levels and the aliases
+// "trace", "debug"/"dbg", "info"/"inf"/"notice",
+// "warn"/"warning"/"wrn", "error"/"err",
+// and "fatal"/"panic"/"crit"/"critical"/"alert"/"emerg".
+//
+// Go panics and runtime fatal errors starting with "panic: "
+// or "fatal error: " are logged at fatal level,
+// goroutine stack dumps starting with "goroutine N [" at error level.
+// All other text is logged with the default level of the writer.
+//
+// A date and time prefix in the format of log.LstdFlags is removed
+// because the Logger adds its own timestamp.
+//
+// Everything written up to the last newline of a Write call
+// is logged as a single message, so multi-line messages
+// written with one call stay together.
+// Text after the last newline is buffered until the next newline
+// is written or Flush is called.
+type LevelDetectingWriter struct {
+	logger       *Logger
+	defaultLevel Level
+
+	mutex   sync.Mutex
+	pending []byte
+}
+
+// Write implements io.Writer
+func (w *LevelDetectingWriter) Write(data []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	last := bytes.LastIndexByte(data, '\n')
+	if last < 0 {
+		w.pending = append(w.pending, data...)
+		return len(data), nil
+	}
+	msg := string(w.pending) + string(data[:last])
+	w.pending = append(w.pending[:0], data[last+1:]...)
+	w.WriteMessage(context.Background(), msg)
+	return len(data), nil
+}
+
+// Flush logs text buffered by Write that was not terminated by a newline.
+func (w *LevelDetectingWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.pending) == 0 {
+		return
+	}
+	msg := string(w.pending)
+	w.pending = w.pending[:0]
+	w.WriteMessage(context.Background(), msg)
+}
+
+// WriteMessage writes a string message
+// with the level detected from its prefix.
+func (w *LevelDetectingWriter) WriteMessage(ctx context.Context, msg string) {
+	if w.logger == nil {
+		return
+	}
+	msg = strings.TrimRight(msg, "\r\n")
+	if msg == "" {
+		return
+	}
+	level, text, found := detectLevel(w.logger.config.Levels(), msg)
+	if !found {
+		level = w.defaultLevel
+	}
+	w.logger.NewMessage(ctx, level, text).Log()
+}
+
+func (w *LevelDetectingWriter) Print(v ...any) {
+	w.WriteMessage(context.Background(), fmt.Sprint(v...))
+}
+
+func (w *LevelDetectingWriter) Println(v ...any) {
+	w.WriteMessage(context.Background(), fmt.Sprintln(v...))
+}
+
+func (w *LevelDetectingWriter) Printf(format string, v ...any) {
+	w.WriteMessage(context.Background(), fmt.Sprintf(format, v...))
+}
+
+// Func returns a function with the log.Printf call signature.
+func (w *LevelDetectingWriter) Func() func(format string, v ...any) {
+	return func(format string, v ...any) {
+		w.Printf(format, v...)
+	}
+}
+
+// StdLogger returns a new log.Logger that writes to the LevelDetectingWriter.
+// See https://golang.org/pkg/log/
+func (w *LevelDetectingWriter) StdLogger() *log.Logger {
+	return log.New(w, "", 0)
+}
+
+// detectLevel returns the level detected from the prefix of msg
+// and the message text without the level marker.
+// If no level was detected then msg is returned unchanged
+// with found as false.
+func detectLevel(levels *Levels, msg string) (level Level, text string, found bool) {
+	text = strings.TrimLeft(trimStdLogTimestamp(msg), " \t")
+
+	switch {
+	case strings.HasPrefix(text, "panic: ") || strings.HasPrefix(text, "fatal error: "):
+		return levels.Fatal, text, true
+	case isGoroutineDump(text):
+		return levels.Error, text, true
+	}
+
+	// "[ERROR] text"
+	if rest, ok := strings.CutPrefix(text, "["); ok {
+		if name, rest, ok := strings.Cut(rest, "]"); ok {
+			if level, ok := levelOfNameOrAlias(levels, name); ok {
+				return level, strings.TrimLeft(rest, " \t"), true
+			}
+		}
+	}
+
+	// "ERROR: text"
+	if name, rest, ok := strings.Cut(text, ":"); ok && !strings.ContainsAny(name, " \t\n") {
+		if level, ok := levelOfNameOrAlias(levels, name); ok {
+			return level, strings.TrimLeft(rest, " \t"), true
+		}
+	}
+
+	// "level=error text"
+	firstLine, _, _ := strings.Cut(text, "\n")
+	for _, token := range strings.Fields(firstLine) {
+		name, ok := strings.CutPrefix(token, "level=")
+		if !ok {
+			name, ok = strings.CutPrefix(token, "lvl=")
+		}
+		if !ok {
+			continue
+		}
+		if level, ok := levelOfNameOrAlias(levels, strings.Trim(name, `"`)); ok {
+			i := strings.Index(text, token)
+			rest := strings.TrimRight(text[:i], " \t") + " " + strings.TrimLeft(text[i+len(token):], " \t")
+			return level, strings.TrimSpace(rest), true
+		}
+	}
+
+	return LevelInvalid, msg, false
+}
+
+// levelOfNameOrAlias returns the level of a name of levels
+// or of a common level name alias, both case insensitive.
+func levelOfNameOrAlias(levels *Levels, name string) (Level, bool) {
+	if name == "" {
+		return LevelInvalid, false
+	}
+	switch strings.ToLower(name) {
+	case "trace":
+		return levels.Trace, true
+	case "debug", "dbg":
+		return levels.Debug, true
+	case "info", "inf", "notice":
+		return levels.Info, true
+	case "warn", "warning", "wrn":
+		return levels.Warn, true
+	case "error", "err":
+		return levels.Error, true
+	case "fatal", "panic", "crit", "critical", "alert", "emerg":
+		return levels.Fatal, true
+	}
+	for level, levelName := range levels.Names {
+		if strings.EqualFold(name, levelName) {
+			return level, true
+		}
+	}
+	return LevelInvalid, false
+}
+
+// isGoroutineDump returns true if text starts
+// like a goroutine stack dump: "goroutine 1 [running]:"
+func isGoroutineDump(text string) bool {
+	rest, ok := strings.CutPrefix(text, "goroutine ")
+	if !ok {
+		return false
+	}
+	digits := 0
+	for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+		digits++
+	}
+	return digits > 0 && strings.HasPrefix(rest[digits:], " [")
+}
+
+// trimStdLogTimestamp removes a date and time prefix
+// in the format written by log.LstdFlags
+// with optional microseconds: "2009/01/23 01:23:23.123123 "
+func trimStdLogTimestamp(msg string) string {
+	const date = "2006/01/02 "
+	if len(msg) >= len(date) && isDigits(msg[0:4]) && msg[4] == '/' && isDigits(msg[5:7]) && msg[7] == '/' && isDigits(msg[8:10]) && msg[10] == ' ' {
+		msg = msg[len(date):]
+	}
+	const clock = "15:04:05"
+	if len(msg) >= len(clock) && isDigits(msg[0:2]) && msg[2] == ':' && isDigits(msg[3:5]) && msg[5] == ':' && isDigits(msg[6:8]) {
+		rest := msg[len(clock):]
+		if frac, ok := strings.CutPrefix(rest, "."); ok {
+			n := 0
+			for n < len(frac) && isDigits(frac[n:n+1]) {
+				n++
+			}
+			rest = frac[n:]
+		}
+		if rest, ok := strings.CutPrefix(rest, " "); ok {
+			msg = rest
+		}
+	}
+	return msg
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
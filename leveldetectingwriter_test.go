@@ -0,0 +1,84 @@
+package golog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_detectLevel(t *testing.T) {
+	tests := []struct {
+		msg       string
+		wantLevel Level
+		wantText  string
+		wantFound bool
+	}{
+		{msg: "Hello", wantLevel: LevelInvalid, wantText: "Hello"},
+		{msg: "[ERROR] Failed", wantLevel: DefaultLevels.Error, wantText: "Failed", wantFound: true},
+		{msg: "[warning]Careful", wantLevel: DefaultLevels.Warn, wantText: "Careful", wantFound: true},
+		{msg: "[request] Hello", wantLevel: LevelInvalid, wantText: "[request] Hello"},
+		{msg: "WARN: Careful", wantLevel: DefaultLevels.Warn, wantText: "Careful", wantFound: true},
+		{msg: "debug: value=1", wantLevel: DefaultLevels.Debug, wantText: "value=1", wantFound: true},
+		{msg: "Note: Hello", wantLevel: LevelInvalid, wantText: "Note: Hello"},
+		{msg: "level=debug msg=Hello", wantLevel: DefaultLevels.Debug, wantText: "msg=Hello", wantFound: true},
+		{msg: `time=now lvl="ERR" msg=Failed`, wantLevel: DefaultLevels.Error, wantText: "time=now msg=Failed", wantFound: true},
+		{msg: "2009/11/10 23:00:00 [INFO] Started", wantLevel: DefaultLevels.Info, wantText: "Started", wantFound: true},
+		{msg: "23:00:00.123456 TRACE: x", wantLevel: DefaultLevels.Trace, wantText: "x", wantFound: true},
+		{msg: "panic: boom\n\ngoroutine 1 [running]:\nmain.main()", wantLevel: DefaultLevels.Fatal, wantText: "panic: boom\n\ngoroutine 1 [running]:\nmain.main()", wantFound: true},
+		{msg: "fatal error: all goroutines are asleep", wantLevel: DefaultLevels.Fatal, wantText: "fatal error: all goroutines are asleep", wantFound: true},
+		{msg: "goroutine 7 [chan receive]:\nmain.worker()", wantLevel: DefaultLevels.Error, wantText: "goroutine 7 [chan receive]:\nmain.worker()", wantFound: true},
+		{msg: "goroutine leak detected", wantLevel: LevelInvalid, wantText: "goroutine leak detected"},
+		{msg: "[CRITICAL] Disk full", wantLevel: DefaultLevels.Fatal, wantText: "Disk full", wantFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			level, text, found := detectLevel(&DefaultLevels, tt.msg)
+			assert.Equal(t, tt.wantLevel, level, "level")
+			assert.Equal(t, tt.wantText, text, "text")
+			assert.Equal(t, tt.wantFound, found, "found")
+		})
+	}
+}
+
+func TestLevelDetectingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer))
+	writer := NewLogger(config).NewLevelDetectingWriter(DefaultLevels.Info)
+
+	fmt.Fprint(writer, "[ERROR] Multi\nline\n")
+	fmt.Fprint(writer, "WARN: Partial")
+	fmt.Fprint(writer, " line\nUnterminated")
+	assert.Equal(t, " |ERROR| Multi\nline\n |WARN | Partial line\n", buf.String())
+
+	buf.Reset()
+	writer.Flush()
+	assert.Equal(t, " |INFO | Unterminated\n", buf.String())
+
+	buf.Reset()
+	writer.StdLogger().Printf("level=debug took=%dms", 5)
+	assert.Equal(t, " |DEBUG| took=5ms\n", buf.String())
+}
+
+func TestLogger_RedirectStdLog(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer))
+
+	restore := NewLogger(config).RedirectStdLog(DefaultLevels.Warn)
+	log.Print("Hello")
+	log.Print("[error] Failed")
+	restore()
+	assert.Equal(t, " |WARN | Hello\n |ERROR| Failed\n", buf.String())
+}
+
+func TestLevelDetectingWriter_source(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer))
+	stdLog := NewLogger(config).WithSource(0).NewLevelDetectingWriter(DefaultLevels.Info).StdLogger()
+
+	stdLog.Print("Hello")
+	source := previousLineSource()
+	assert.Equal(t, " |INFO | Hello source="+source.String()+"\n", buf.String())
+}
@@ -0,0 +1,487 @@
+package golog
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	_ WriterConfig      = new(LimitsWriterConfig)
+	_ Writer            = new(limitsWriter)
+	_ ErrorChainWriter  = new(limitsWriter)
+	_ SourceWriter      = new(limitsWriter)
+	_ CommitErrorWriter = new(limitsWriter)
+)
+
+// TruncatedKey is the key of the attrib with the value true
+// added to messages where values were truncated or dropped
+// because of Limits.
+const TruncatedKey = "truncated"
+
+// Limits for the size of logged values and messages
+// to prevent huge log lines.
+// Zero or negative values disable a limit.
+//
+// Messages with truncated or dropped values get
+// an additional TruncatedKey attrib with the value true.
+//
+// Limits can be set for TextWriterConfig and JSONWriterConfig
+// with Format.Limits and for any other WriterConfig
+// with LimitsWriterConfig.
+type Limits struct {
+	// MaxSliceElements limits the number of logged slice elements.
+	// Further elements are replaced by a string element "...+N more".
+	MaxSliceElements int
+
+	// MaxStringLength limits the number of runes
+	// of logged strings and error messages
+	// including the messages of error chains.
+	// Longer values are cut and get a "..." suffix.
+	MaxStringLength int
+
+	// MaxJSONBytes limits the size of logged JSON values.
+	// Larger values are logged as string with the cut JSON
+	// and a "..." suffix.
+	MaxJSONBytes int
+
+	// MaxMessageBytes limits the size of a message.
+	// String, error, and JSON values exceeding the remaining
+	// bytes are cut with a "..." suffix, slice elements
+	// after the limit are skipped, and attribs starting
+	// after the limit was reached are dropped.
+	// Error chains are cut at the first message exceeding
+	// the remaining bytes and lose the attribs and causes
+	// that don't fit anymore.
+	// The size is exact for TextWriter and JSONWriter
+	// including timestamp, level, and message text,
+	// except for error chains whose size is approximated,
+	// for other writers the size of keys and values is approximated.
+	MaxMessageBytes int
+}
+
+// IsActive returns true if any limit is set.
+func (l *Limits) IsActive() bool {
+	return l != nil && (l.MaxSliceElements > 0 || l.MaxStringLength > 0 || l.MaxJSONBytes > 0 || l.MaxMessageBytes > 0)
+}
+
+// LimitWriter returns a Writer that enforces limits
+// on the values written to the wrapped Writer.
+// Returns wrapped unchanged if wrapped is nil or limits are not active.
+//
+// Writer implementations in other packages can use it
+// in their WriterConfig.WriterForNewMessage method.
+func LimitWriter(wrapped Writer, limits *Limits) Writer {
+	if wrapped == nil || !limits.IsActive() {
+		return wrapped
+	}
+	w := limitsWriterPool.GetOrNew()
+	w.wrapped = wrapped
+	w.limits = limits
+	return w
+}
+
+// LimitsWriterConfig wraps a WriterConfig and enforces
+// Limits on the values of all messages written by it.
+type LimitsWriterConfig struct {
+	limits  Limits
+	wrapped WriterConfig
+}
+
+// NewLimitsWriterConfig returns a LimitsWriterConfig
+// enforcing limits for the writers of wrapped.
+// Panics if wrapped is nil.
+func NewLimitsWriterConfig(limits Limits, wrapped WriterConfig) *LimitsWriterConfig {
+	if wrapped == nil {
+		panic("golog.LimitsWriterConfig needs a WriterConfig to wrap") // Panic during setup is acceptable
+	}
+	return &LimitsWriterConfig{
+		limits:  limits,
+		wrapped: wrapped,
+	}
+}
+
+// IsActive implements the LevelDecider interface
+// by asking the wrapped WriterConfig.
+func (c *LimitsWriterConfig) IsActive(ctx context.Context, level Level) bool {
+	return WriterConfigIsActive(ctx, c.wrapped, level)
+}
+
+func (c *LimitsWriterConfig) WriterForNewMessage(ctx context.Context, level Level) Writer {
+	return LimitWriter(c.wrapped.WriterForNewMessage(ctx, level), &c.limits)
+}
+
+func (c *LimitsWriterConfig) FlushUnderlying() {
+	c.wrapped.FlushUnderlying()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// bufferWriter is implemented by writers
+// that can report the exact size of the current message
+type bufferWriter interface {
+	bufferLen() int
+}
+
+type limitsWriter struct {
+	wrapped Writer
+	limits  *Limits
+
+	dropping     bool // MaxMessageBytes reached
+	truncated    bool
+	inSlice      bool
+	sliceLen     int
+	sliceSkipped int
+	approxBytes  int
+}
+
+// messageBytes returns the size of the message written so far
+func (w *limitsWriter) messageBytes() int {
+	if b, ok := w.wrapped.(bufferWriter); ok {
+		return b.bufferLen()
+	}
+	return w.approxBytes
+}
+
+// skipValue returns true if the next value must not be written
+// because the message is too large or the slice has too many elements.
+func (w *limitsWriter) skipValue() bool {
+	if w.dropping {
+		return true
+	}
+	if w.inSlice {
+		if (w.limits.MaxSliceElements > 0 && w.sliceLen >= w.limits.MaxSliceElements) ||
+			(w.sliceLen > 0 && w.remainingBytes() <= 0) {
+			w.sliceSkipped++
+			w.truncated = true
+			return true
+		}
+		w.sliceLen++
+	}
+	return false
+}
+
+// remainingBytes returns the number of bytes left
+// until MaxMessageBytes is reached or math.MaxInt
+// if MaxMessageBytes is not limited.
+func (w *limitsWriter) remainingBytes() int {
+	if w.limits.MaxMessageBytes <= 0 {
+		return math.MaxInt
+	}
+	return w.limits.MaxMessageBytes - w.messageBytes()
+}
+
+// cutToRemainingBytes cuts val so that it fits
+// together with overhead bytes and a "..." suffix
+// into the remaining bytes of MaxMessageBytes.
+// All following attribs are dropped if val was cut.
+func (w *limitsWriter) cutToRemainingBytes(val string, overhead int) string {
+	remaining := w.remainingBytes()
+	if len(val)+overhead <= remaining {
+		return val
+	}
+	w.truncated = true
+	w.dropping = !w.inSlice // Slices skip remaining elements
+	return cutWithEllipsis(val, remaining-overhead)
+}
+
+// cutWithEllipsis cuts val so that it fits
+// together with a "..." suffix into n bytes.
+func cutWithEllipsis(val string, n int) string {
+	n = max(n-3, 0)
+	// Don't cut in the middle of a UTF-8 encoded rune
+	for n > 0 && !utf8.RuneStart(val[n]) {
+		n--
+	}
+	return val[:n] + "..."
+}
+
+// checkMessageBytes starts dropping all following attribs
+// if the message already reached MaxMessageBytes.
+func (w *limitsWriter) checkMessageBytes() {
+	if !w.dropping && w.limits.MaxMessageBytes > 0 && w.messageBytes() >= w.limits.MaxMessageBytes {
+		w.dropping = true
+		w.truncated = true
+	}
+}
+
+func (w *limitsWriter) BeginMessage(config Config, timestamp time.Time, level Level, prefix, text string) {
+	w.approxBytes = len(prefix) + len(text)
+	w.wrapped.BeginMessage(config, timestamp, level, prefix, text)
+}
+
+func (w *limitsWriter) CommitMessage() {
+	if err := w.CommitMessageError(); err != nil && ErrorHandler != nil {
+		ErrorHandler(err)
+	}
+}
+
+func (w *limitsWriter) CommitMessageError() (err error) {
+	if w.truncated {
+		w.wrapped.WriteKey(TruncatedKey)
+		w.wrapped.WriteBool(true)
+	}
+	if cw, ok := w.wrapped.(CommitErrorWriter); ok {
+		err = cw.CommitMessageError()
+	} else {
+		w.wrapped.CommitMessage()
+	}
+
+	// Reset and return to pool
+	*w = limitsWriter{}
+	limitsWriterPool.PutBack(w)
+	return err
+}
+
+func (w *limitsWriter) String() string {
+	return w.wrapped.String()
+}
+
+func (w *limitsWriter) WriteKey(key string) {
+	w.checkMessageBytes()
+	if w.dropping {
+		return
+	}
+	w.approxBytes += len(key) + 2
+	w.wrapped.WriteKey(key)
+}
+
+func (w *limitsWriter) WriteSliceKey(key string) {
+	w.checkMessageBytes()
+	if w.dropping {
+		return
+	}
+	w.inSlice = true
+	w.sliceLen = 0
+	w.sliceSkipped = 0
+	w.approxBytes += len(key) + 4
+	w.wrapped.WriteSliceKey(key)
+}
+
+func (w *limitsWriter) WriteSliceEnd() {
+	if w.dropping {
+		return
+	}
+	if w.sliceSkipped > 0 {
+		w.wrapped.WriteString("...+" + strconv.Itoa(w.sliceSkipped) + " more")
+	}
+	w.inSlice = false
+	w.wrapped.WriteSliceEnd()
+}
+
+func (w *limitsWriter) WriteNil() {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 4
+	w.wrapped.WriteNil()
+}
+
+func (w *limitsWriter) WriteBool(val bool) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 5
+	w.wrapped.WriteBool(val)
+}
+
+func (w *limitsWriter) WriteInt(val int64) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 20
+	w.wrapped.WriteInt(val)
+}
+
+func (w *limitsWriter) WriteUint(val uint64) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 20
+	w.wrapped.WriteUint(val)
+}
+
+func (w *limitsWriter) WriteFloat(val float64) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 24
+	w.wrapped.WriteFloat(val)
+}
+
+func (w *limitsWriter) WriteString(val string) {
+	if w.skipValue() {
+		return
+	}
+	val = w.cutToRemainingBytes(w.truncateString(val), 2)
+	w.approxBytes += len(val) + 2
+	w.wrapped.WriteString(val)
+}
+
+func (w *limitsWriter) WriteError(val error) {
+	if w.skipValue() {
+		return
+	}
+	w.writeError(val)
+}
+
+func (w *limitsWriter) writeError(val error) {
+	if val != nil {
+		msg := val.Error()
+		if cut := w.cutToRemainingBytes(w.truncateString(msg), 2); cut != msg {
+			val = errors.New(cut)
+		}
+		w.approxBytes += len(val.Error()) + 2
+	}
+	w.wrapped.WriteError(val)
+}
+
+func (w *limitsWriter) WriteTime(val time.Time) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 37
+	w.wrapped.WriteTime(val)
+}
+
+func (w *limitsWriter) WriteDuration(val time.Duration) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 20
+	w.wrapped.WriteDuration(val)
+}
+
+func (w *limitsWriter) WriteUUID(val [16]byte) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 38
+	w.wrapped.WriteUUID(val)
+}
+
+func (w *limitsWriter) WriteJSON(val []byte) {
+	if w.skipValue() {
+		return
+	}
+	if max := w.limits.MaxJSONBytes; max > 0 && len(val) > max {
+		// Don't cut in the middle of a UTF-8 encoded rune
+		for max > 0 && !utf8.RuneStart(val[max]) {
+			max--
+		}
+		w.truncated = true
+		str := w.cutToRemainingBytes(string(val[:max])+"...", 2)
+		w.approxBytes += len(str) + 2
+		w.wrapped.WriteString(str)
+		return
+	}
+	if len(val) > w.remainingBytes() {
+		// Write cut JSON as string to keep the output valid
+		str := w.cutToRemainingBytes(string(val), 2)
+		w.approxBytes += len(str) + 2
+		w.wrapped.WriteString(str)
+		return
+	}
+	w.approxBytes += len(val)
+	w.wrapped.WriteJSON(val)
+}
+
+func (w *limitsWriter) WriteErrorChain(chain *ErrorChain) {
+	if w.skipValue() {
+		return
+	}
+	cw, ok := w.wrapped.(ErrorChainWriter)
+	if !ok {
+		w.writeError(chain.Err)
+		return
+	}
+	budget := w.remainingBytes()
+	cw.WriteErrorChain(w.limitErrorChain(chain, &budget))
+}
+
+// errorChainOverhead approximates the bytes written
+// around the message and type of every ErrorChain
+const errorChainOverhead = 24
+
+// limitErrorChain returns a copy of chain with its messages
+// truncated to MaxStringLength and cut to fit into budget bytes.
+// Attribs and causes that don't fit into budget are dropped
+// and all following attribs of the message are dropped
+// if the chain had to be cut.
+func (w *limitsWriter) limitErrorChain(chain *ErrorChain, budget *int) *ErrorChain {
+	limited := &ErrorChain{Err: chain.Err, Type: chain.Type}
+	*budget -= len(chain.Type) + errorChainOverhead
+	limited.Message = w.truncateString(chain.Message)
+	if len(limited.Message) > *budget {
+		limited.Message = cutWithEllipsis(limited.Message, *budget)
+		w.truncated = true
+		w.dropping = !w.inSlice // Slices skip remaining elements
+		*budget = 0
+	} else {
+		*budget -= len(limited.Message)
+	}
+	w.approxBytes += len(limited.Message) + len(chain.Type) + errorChainOverhead
+
+	if len(chain.Attribs) > 0 {
+		if size := len(chain.Attribs.AppendJSON(nil)); size <= *budget {
+			limited.Attribs = chain.Attribs
+			*budget -= size
+			w.approxBytes += size
+		} else {
+			w.truncated = true
+		}
+	}
+	for _, cause := range chain.Causes {
+		if *budget <= 0 {
+			w.truncated = true
+			break
+		}
+		limited.Causes = append(limited.Causes, w.limitErrorChain(cause, budget))
+	}
+	return limited
+}
+
+func (w *limitsWriter) WriteSource(source Source) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += len(source.File) + 6
+	writeSource(w.wrapped, source)
+}
+
+func (w *limitsWriter) anyJSONConfig(config *anyJSONConfig) {
+	if c, ok := w.wrapped.(anyJSONConfigWriter); ok {
+		c.anyJSONConfig(config)
+	}
+	config.limits = w.limits
+	config.truncated = &w.truncated
+}
+
+func (w *limitsWriter) writeAnyDuration(val time.Duration) {
+	if w.skipValue() {
+		return
+	}
+	w.approxBytes += 20
+	writeAnyDuration(w.wrapped, val)
+}
+
+// truncateString cuts val after MaxStringLength runes
+// and adds a "..." suffix.
+func (w *limitsWriter) truncateString(val string) string {
+	max := w.limits.MaxStringLength
+	if max <= 0 || len(val) <= max {
+		return val
+	}
+	numRunes := 0
+	for byteIndex := range val {
+		if numRunes == max {
+			w.truncated = true
+			return val[:byteIndex] + "..."
+		}
+		numRunes++
+	}
+	return val
+}
@@ -0,0 +1,152 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimits_IsActive(t *testing.T) {
+	var nilLimits *Limits
+	assert.False(t, nilLimits.IsActive())
+	assert.False(t, (&Limits{}).IsActive())
+	assert.False(t, (&Limits{MaxStringLength: -1}).IsActive())
+	assert.True(t, (&Limits{MaxSliceElements: 1}).IsActive())
+	assert.True(t, (&Limits{MaxMessageBytes: 1}).IsActive())
+}
+
+func TestLimits_values(t *testing.T) {
+	var textBuf, jsonBuf bytes.Buffer
+	format := &Format{Limits: Limits{
+		MaxSliceElements: 2,
+		MaxStringLength:  5,
+		MaxJSONBytes:     8,
+	}}
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewTextWriterConfig(&textBuf, format, NoColorizer),
+		NewJSONWriterConfig(&jsonBuf, format),
+	))
+
+	log.Info("Msg").
+		Ints("ints", []int{1, 2, 3, 4}).
+		Str("str", "Hällo World").
+		Error("err", errors.New("long error")).
+		JSON("json", []byte(`{"a":"äöü"}`)).
+		Log()
+
+	assert.Equal(t, " |INFO | Msg ints=[1,2,\"...+2 more\"] str=\"Hällo...\" err=`long ...` json=\"{\\\"a\\\":\\\"ä...\" truncated=true\n", textBuf.String())
+	assert.Equal(t, `{"ints":[1,2,"...+2 more"],"str":"Hällo...","err":"long ...","json":"{\"a\":\"ä...","truncated":true}`+"\n", jsonBuf.String())
+
+	// Values within the limits are not changed
+	textBuf.Reset()
+	jsonBuf.Reset()
+	log.Info("Msg").Ints("ints", []int{1, 2}).Str("str", "Hello").JSON("json", []byte(`[1,2,3]`)).Log()
+	assert.Equal(t, ` |INFO | Msg ints=[1,2] str="Hello" json=[1,2,3]`+"\n", textBuf.String())
+	assert.Equal(t, `{"ints":[1,2],"str":"Hello","json":[1,2,3]}`+"\n", jsonBuf.String())
+}
+
+func TestLimits_MaxMessageBytes(t *testing.T) {
+	var jsonBuf bytes.Buffer
+	format := &Format{Limits: Limits{MaxMessageBytes: 15}}
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewJSONWriterConfig(&jsonBuf, format),
+	))
+
+	log.Info("").Str("a", "0123456789").Str("b", "dropped").Ints("c", []int{1}).Log()
+	assert.Equal(t, `{"a":"01234...","truncated":true}`+"\n", jsonBuf.String())
+
+	// A single value exceeding the limit is cut
+	// to the remaining bytes of the message
+	format.Limits.MaxMessageBytes = 20
+	for _, tt := range []struct {
+		name string
+		msg  *Message
+		want string
+	}{
+		{"Str", log.Info("").Str("a", strings.Repeat("x", 1000)), `{"a":"xxxxxxxxxx..."`},
+		{"Error", log.Info("").Error("a", errors.New(strings.Repeat("x", 1000))), `{"a":"xxxxxxxxxx..."`},
+		{"JSON", log.Info("").JSON("a", []byte(`["`+strings.Repeat("x", 1000)+`"]`)), `{"a":"[\"xxxxxxxx..."`},
+		{"Any", log.Info("").Any("a", map[string]string{"x": strings.Repeat("x", 1000)}), `{"a":"{\"x\":\"xxxx..."`},
+		{"Strs", log.Info("").Strs("a", []string{"x", strings.Repeat("x", 1000), "x"}), `{"a":["x","xxxxxx...","...+1 more"]`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonBuf.Reset()
+			tt.msg.Int("dropped", 1).Log()
+			assert.Equal(t, tt.want+`,"truncated":true}`+"\n", jsonBuf.String())
+		})
+	}
+}
+
+func TestLimits_ErrorChain(t *testing.T) {
+	var textBuf, jsonBuf bytes.Buffer
+	format := &Format{Limits: Limits{MaxStringLength: 5}}
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewTextWriterConfig(&textBuf, format, NoColorizer),
+		NewJSONWriterConfig(&jsonBuf, format),
+	))
+	err := fmt.Errorf("wrapped: %w", errors.New("long error"))
+
+	log.Info("").ErrorChain("err", err).Log()
+	assert.Equal(t, " |INFO | err=`wrapp...` (*fmt.wrapError) <- `long ...` (*errors.errorString) truncated=true\n", textBuf.String())
+	assert.Equal(t, `{"err":{"message":"wrapp...","type":"*fmt.wrapError","causes":[{"message":"long ...","type":"*errors.errorString"}]},"truncated":true}`+"\n", jsonBuf.String())
+
+	// Causes after MaxMessageBytes are dropped
+	format.Limits = Limits{MaxMessageBytes: 60}
+	jsonBuf.Reset()
+	log.Info("").ErrorChain("err", fmt.Errorf("%w", errors.New(strings.Repeat("x", 1000)))).Int("dropped", 1).Log()
+	assert.Equal(t, `{"err":{"message":"xxxxxxxxxxxx...","type":"*fmt.wrapError"},"truncated":true}`+"\n", jsonBuf.String())
+}
+
+func TestLimitsWriterConfig(t *testing.T) {
+	var (
+		recorded Attribs
+		text     string
+	)
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewLimitsWriterConfig(
+		Limits{MaxSliceElements: 2, MaxStringLength: 3, MaxMessageBytes: 78},
+		NewCallbackWriterConfig(
+			func(timestamp time.Time, level Level, prefix, msg string, attribs Attribs) {
+				text = msg
+				recorded = attribs.Clone()
+			},
+		),
+	))
+	log := NewLogger(config)
+
+	log.Info("Msg").
+		Ints("ints", []int{1, 2, 3}).
+		Str("str", "abcdef").
+		Str("long", strings.Repeat("x", 100)).
+		Str("dropped", "x").
+		Log()
+
+	assert.Equal(t, "Msg", text)
+	require.Len(t, recorded, 4)
+	assert.Equal(t, []any{int64(1), int64(2), "...+1 more"}, recorded.Get("ints").Value())
+	assert.Equal(t, "abc...", recorded.Get("str").Value())
+	assert.Equal(t, "xxx...", recorded.Get("long").Value())
+	assert.Nil(t, recorded.Get("dropped"))
+	assert.Equal(t, true, recorded.Get(TruncatedKey).Value())
+}
+
+func TestAttribsRecorder_mixedSlice(t *testing.T) {
+	var r attribsRecorder
+	r.WriteSliceKey("mixed")
+	r.WriteInt(1)
+	r.WriteString("two")
+	r.WriteBool(true)
+	r.WriteSliceEnd()
+
+	require.Len(t, r.attribs, 1)
+	a, ok := r.attribs[0].(*Anys)
+	require.True(t, ok, "mixed slice recorded as Anys")
+	assert.Equal(t, "mixed", a.Key())
+	assert.Equal(t, []any{int64(1), "two", true}, a.Value())
+	assert.Equal(t, `"mixed":[1,"two",true]`, string(a.AppendJSON(nil)))
+}
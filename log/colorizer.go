@@ -10,7 +10,13 @@ import (
 // Supports NO_COLOR (https://no-color.org/)
 // and CLICOLOR/CLICOLOR_FORCE (https://bixense.com/clicolors/)
 func NewStyledColorizer() *golog.StyledColorizer {
-	profile := termenv.EnvColorProfile()
+	return NewStyledColorizerWithProfile(termenv.EnvColorProfile())
+}
+
+// NewStyledColorizerWithProfile creates a new golog.StyledColorizer
+// using the passed color profile independent of the environment.
+// termenv.Ascii disables colors.
+func NewStyledColorizerWithProfile(profile termenv.Profile) *golog.StyledColorizer {
 	return &golog.StyledColorizer{
 		TimespampStyle: termenv.Style{}.Foreground(profile.Color("#A0A0A0")),
 
@@ -22,16 +28,17 @@ func NewStyledColorizer() *golog.StyledColorizer {
 		DebugLevelStyle: termenv.Style{}.Foreground(profile.Color("#A0A0F0")),
 		TraceLevelStyle: termenv.Style{}.Foreground(profile.Color("#808080")),
 
-		MsgStyle:    termenv.Style{}.Foreground(profile.Color("#FFFFFF")),
-		KeyStyle:    termenv.Style{}.Foreground(profile.Color("#00DDDD")),
-		NilStyle:    termenv.Style{}.Foreground(profile.Color("#F0F0F0")).Italic(),
-		TrueStyle:   termenv.Style{}.Foreground(profile.Color("#00CC00")),
-		FalseStyle:  termenv.Style{}.Foreground(profile.Color("#BB0000")),
-		IntStyle:    termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
-		UintStyle:   termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
-		FloatStyle:  termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
-		UUIDStyle:   termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
-		StringStyle: termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
-		ErrorStyle:  termenv.Style{}.Foreground(profile.Color("#FFFFFF")).Background(profile.Color("#B00000")),
+		MsgStyle:      termenv.Style{}.Foreground(profile.Color("#FFFFFF")),
+		KeyStyle:      termenv.Style{}.Foreground(profile.Color("#00DDDD")),
+		NilStyle:      termenv.Style{}.Foreground(profile.Color("#F0F0F0")).Italic(),
+		TrueStyle:     termenv.Style{}.Foreground(profile.Color("#00CC00")),
+		FalseStyle:    termenv.Style{}.Foreground(profile.Color("#BB0000")),
+		IntStyle:      termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
+		UintStyle:     termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
+		FloatStyle:    termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
+		UUIDStyle:     termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
+		DurationStyle: termenv.Style{}.Foreground(profile.Color("#D0D0FF")),
+		StringStyle:   termenv.Style{}.Foreground(profile.Color("#F0F0F0")),
+		ErrorStyle:    termenv.Style{}.Foreground(profile.Color("#FFFFFF")).Background(profile.Color("#B00000")),
 	}
 }
@@ -232,3 +232,15 @@ func DebugWriter() *golog.LevelWriter {
 func TraceWriter() *golog.LevelWriter {
 	return Logger.TraceWriter()
 }
+
+func NewLevelDetectingWriter(defaultLevel golog.Level) *golog.LevelDetectingWriter {
+	return Logger.NewLevelDetectingWriter(defaultLevel)
+}
+
+// RedirectStdLog redirects the output of the standard library's
+// default log.Logger to Logger with the info level as default
+// for lines without detected level.
+// See [golog.Logger.RedirectStdLog]
+func RedirectStdLog() (restore func()) {
+	return Logger.RedirectStdLog(Logger.Config().InfoLevel())
+}
@@ -3,15 +3,20 @@ package golog
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 )
 
 // Logger starts new log messages.
 // A nil Logger is valid to use but will not log anything.
 type Logger struct {
-	config  Config  // Can be shared between loggers
-	prefix  string  // Prefix for every log message
-	attribs Attribs // Attributes that will be repeated for every message
+	config     Config                // Can be shared between loggers
+	prefix     string                // Prefix for every log message
+	attribs    Attribs               // Attributes that will be repeated for every message
+	source     bool                  // Capture the source location of every message
+	sourceSkip int                   // Additional call frames to skip for the source location
+	namespace  string                // Prefix for the keys of attribs added to messages
+	collision  AttribCollisionPolicy // Policy for attribs with already logged keys
 }
 
 // NewLogger returns a Logger with the given config and per message attributes.
@@ -51,9 +56,13 @@ func (l *Logger) Clone() *Logger {
 		return nil
 	}
 	return &Logger{
-		config:  l.config,
-		prefix:  l.prefix,
-		attribs: l.attribs.Clone(),
+		config:     l.config,
+		prefix:     l.prefix,
+		attribs:    l.attribs.Clone(),
+		source:     l.source,
+		sourceSkip: l.sourceSkip,
+		namespace:  l.namespace,
+		collision:  l.collision,
 	}
 }
 
@@ -80,7 +89,69 @@ func (l *Logger) With() *Message {
 	// logger with the recorded attribs.
 	// The new message takes ownership of the
 	// cloned logger attribs.
-	return newMessage(l, l.attribs.Clone(), nil, LevelInvalid, "")
+	m := newRecorderMessage(l, l.attribs.Clone())
+	m.namespace = l.namespace
+	return m
+}
+
+// WithNamespace returns a clone of the logger that prefixes
+// the keys of all attribs added to its messages
+// and to its sub-loggers via Logger.With with namespace and a dot.
+// Attribs of the logger itself and from the context are not prefixed.
+// Namespaces of sub-loggers are nested like "db.query.sql".
+//
+// Example:
+//
+//	dbLog := log.WithNamespace("db")
+//	dbLog.Info("Query").Str("query", query).Log() // logs "db.query"
+//
+// Returns nil if the logger was nil.
+func (l *Logger) WithNamespace(namespace string) *Logger {
+	if l == nil {
+		return nil
+	}
+	clone := *l
+	clone.attribs = l.attribs.Clone()
+	if namespace != "" {
+		clone.namespace = l.namespace + namespace + "."
+	}
+	return &clone
+}
+
+// WithAttribKeyCollision returns a clone of the logger using policy
+// for attribs logged with a key that was already logged for a message
+// and for merging attribs into sub-loggers with Logger.WithClonedAttribs.
+// Sub-loggers inherit the policy.
+// The default is AttribCollisionLoggerWins.
+func (l *Logger) WithAttribKeyCollision(policy AttribCollisionPolicy) *Logger {
+	if l == nil {
+		return nil
+	}
+	clone := *l
+	clone.attribs = l.attribs.Clone()
+	clone.collision = policy
+	return &clone
+}
+
+// AttribKeyCollision returns the policy of the logger for
+// attribs logged with a key that was already logged for a message.
+// See Logger.WithAttribKeyCollision
+func (l *Logger) AttribKeyCollision() AttribCollisionPolicy {
+	if l == nil {
+		return AttribCollisionLoggerWins
+	}
+	return l.collision
+}
+
+// Namespace returns the prefix of the keys of attribs
+// added to messages of the logger including a trailing dot
+// or an empty string if the logger has no namespace.
+// See Logger.WithNamespace
+func (l *Logger) Namespace() string {
+	if l == nil {
+		return ""
+	}
+	return l.namespace
 }
 
 // WithLevelFilter returns a clone of the logger using
@@ -90,9 +161,13 @@ func (l *Logger) WithLevelFilter(filter LevelFilter) *Logger {
 		return nil
 	}
 	return &Logger{
-		config:  NewDerivedConfigWithFilter(&l.config, filter),
-		prefix:  l.prefix,
-		attribs: l.attribs.Clone(),
+		config:     NewDerivedConfigWithFilter(&l.config, filter),
+		prefix:     l.prefix,
+		attribs:    l.attribs.Clone(),
+		source:     l.source,
+		sourceSkip: l.sourceSkip,
+		namespace:  l.namespace,
+		collision:  l.collision,
 	}
 }
 
@@ -103,9 +178,13 @@ func (l *Logger) WithAdditionalWriterConfigs(writerConfigs ...WriterConfig) *Log
 		return l
 	}
 	return &Logger{
-		config:  ConfigWithAdditionalWriterConfigs(&l.config, writerConfigs...),
-		prefix:  l.prefix,
-		attribs: l.attribs.Clone(),
+		config:     ConfigWithAdditionalWriterConfigs(&l.config, writerConfigs...),
+		prefix:     l.prefix,
+		attribs:    l.attribs.Clone(),
+		source:     l.source,
+		sourceSkip: l.sourceSkip,
+		namespace:  l.namespace,
+		collision:  l.collision,
 	}
 }
 
@@ -140,16 +219,48 @@ func (l *Logger) RemoveAttribs() {
 // WithClonedAttribs returns a new Logger clones of the passed
 // perMessageAttribs merged with the existing Logger attribs
 // using the keys of the existing attribs to identify identical
-// attribs in the passed attribs.
+// attribs in the passed attribs according to the collision policy
+// of the logger where AttribCollisionLoggerWins keeps the existing attribs.
 func (l *Logger) WithClonedAttribs(perMessageAttribs ...Attrib) *Logger {
 	if l == nil || len(perMessageAttribs) == 0 {
 		return l
 	}
 	return &Logger{
-		config:  l.config,
-		prefix:  l.prefix,
-		attribs: l.attribs.CloneAndAppendNonExistingCloned(perMessageAttribs),
+		config:     l.config,
+		prefix:     l.prefix,
+		attribs:    l.attribs.CloneAndMerge(perMessageAttribs, l.collision),
+		source:     l.source,
+		sourceSkip: l.sourceSkip,
+		namespace:  l.namespace,
+		collision:  l.collision,
+	}
+}
+
+// WithReplacedAttribs returns a new Logger with clones of the passed
+// attribs replacing existing Logger attribs with the same keys
+// at their position or appended if there are none.
+func (l *Logger) WithReplacedAttribs(attribs ...Attrib) *Logger {
+	if l == nil || len(attribs) == 0 {
+		return l
+	}
+	clone := *l
+	clone.attribs = l.attribs.Clone()
+	clones := Attribs(attribs).Clone()
+	clone.attribs.Replace(clones...)
+	attribsPool.ClearAndPutBack(clones) // Attribs now owned by clone.attribs
+	return &clone
+}
+
+// WithoutAttribs returns a new Logger without
+// the Logger attribs with the passed keys.
+func (l *Logger) WithoutAttribs(keys ...string) *Logger {
+	if l == nil || len(keys) == 0 {
+		return l
 	}
+	clone := *l
+	clone.attribs = l.attribs.Clone()
+	clone.attribs.Remove(keys...)
+	return &clone
 }
 
 // Prefix returns the prefix string that will be
@@ -170,10 +281,57 @@ func (l *Logger) WithPrefix(prefix string) *Logger {
 		return nil
 	}
 	return &Logger{
-		config:  l.config,
-		attribs: l.attribs,
-		prefix:  prefix,
+		config:     l.config,
+		attribs:    l.attribs,
+		prefix:     prefix,
+		source:     l.source,
+		sourceSkip: l.sourceSkip,
+		namespace:  l.namespace,
+		collision:  l.collision,
+	}
+}
+
+// WithSource returns a clone of the logger that captures
+// the source code location of the logging call for every message
+// and logs it with the key SourceKey.
+//
+// Call frames of Logger methods, of the functions of the
+// github.com/domonda/golog/log package, of the adapter packages
+// goslog, gozap, gozerolog, and gologrus,
+// and of log/slog, zap, zerolog, and logrus are skipped automatically.
+// Pass a positive skip to skip additional frames
+// of functions that wrap the logger.
+//
+// Returns nil if the logger was nil.
+func (l *Logger) WithSource(skip int) *Logger {
+	if l == nil {
+		return nil
 	}
+	clone := *l
+	clone.source = true
+	clone.sourceSkip = max(skip, 0)
+	return &clone
+}
+
+// WithoutSource returns a clone of the logger
+// that does not capture the source code location
+// or returns nil if the logger was nil.
+// See Logger.WithSource
+func (l *Logger) WithoutSource() *Logger {
+	if l == nil {
+		return nil
+	}
+	clone := *l
+	clone.source = false
+	clone.sourceSkip = 0
+	return &clone
+}
+
+// SourceEnabled returns true if the logger captures
+// the source code location of every message.
+// See Logger.WithSource
+func (l *Logger) SourceEnabled() bool {
+	return l != nil && l.source
 }
 
 // IsActive returns if the passed level is active at the logger
@@ -193,6 +351,18 @@ func (l *Logger) Flush() {
 
 // NewMessageAt starts a new message logged with the given timestamp
 func (l *Logger) NewMessageAt(ctx context.Context, timestamp time.Time, level Level, text string) *Message {
+	return l.newMessageAt(ctx, timestamp, level, text, Source{})
+}
+
+// NewMessageWithSource starts a new message logged with the given timestamp
+// and source code location.
+// A non zero source is logged with the key SourceKey independent
+// of Logger.WithSource, a zero source is ignored.
+func (l *Logger) NewMessageWithSource(ctx context.Context, timestamp time.Time, level Level, text string, source Source) *Message {
+	return l.newMessageAt(ctx, timestamp, level, text, source)
+}
+
+func (l *Logger) newMessageAt(ctx context.Context, timestamp time.Time, level Level, text string, source Source) *Message {
 	// Logging should always err on the side of robustness
 	// so accept nil to prevent panics.
 	if ctx == nil {
@@ -201,6 +371,12 @@ func (l *Logger) NewMessageAt(ctx context.Context, timestamp time.Time, level Le
 	if !l.IsActive(ctx, level) {
 		return nil
 	}
+	var callers callerPCs
+	if source.IsZero() && l.source {
+		// Only capture the program counters here,
+		// they are resolved if there are writers for the message
+		callers.capture(0)
+	}
 	configs := l.config.WriterConfigs()
 	if ctxConfigs := WriterConfigsFromContext(ctx); len(ctxConfigs) > 0 {
 		configs = mergeWriterConfigs(configs, ctxConfigs)
@@ -218,23 +394,36 @@ func (l *Logger) NewMessageAt(ctx context.Context, timestamp time.Time, level Le
 			}
 		}
 	}
-	// Get new Message without attribs so that the logger
-	// attribs can get logged without detecting that
-	// attribs with their keys are already present
+	// Get new Message without attribs,
+	// the keys of logged attribs are tracked by the message
 	msg := newMessage(l, nil, writers, level, text)
-	if len(l.attribs) > 0 {
-		for _, attrib := range l.attribs {
-			attrib.Log(msg)
-		}
-		// After logger attribs have been written
-		// set them at the message to prevent
-		// writing more attribs with the same keys
-		msg.attribs = l.attribs.Clone()
+	if msg.collision == AttribCollisionLastWins && len(msg.writers) > 0 {
+		// Record the attribs so that later attribs can replace
+		// earlier ones with the same key before they are written
+		msg.deferredWriters, msg.writers = msg.writers, nil
+	}
+	// The source is logged first like an attrib
+	// so that its key is handled by the collision policy
+	if len(writers) > 0 && callers.n > 0 {
+		source = callers.source(l.sourceSkip)
 	}
+	msg.Source(SourceKey, source)
+	// Logger attribs are logged next.
+	// The keys of the message prevent writing more
+	// attribs with the same keys depending on the collision policy.
+	for _, attrib := range l.attribs {
+		attrib.Log(msg)
+	}
+	// AttribCollisionLoggerWins only checks the keys
+	// of the source and the logger attribs
+	msg.loggerKeysOnly = true
 	// Context attribs are logged after logger attribs
-	// meaning they are ignored if attribs
-	// with the same key were already logged
+	// meaning they are handled as collisions
+	// if attribs with the same key were already logged
 	AttribsFromContext(ctx).Log(msg)
+	// Only the keys of attribs added with
+	// the methods of the message get the namespace
+	msg.namespace = l.namespace
 	// After the attribs from the logger
 	// and the context have been logged,
 	// further attribs can be logged using
@@ -437,3 +626,33 @@ func (l *Logger) DebugWriter() *LevelWriter {
 func (l *Logger) TraceWriter() *LevelWriter {
 	return l.NewLevelWriter(l.config.TraceLevel())
 }
+
+// NewLevelDetectingWriter returns an io.Writer that logs written lines
+// with the level detected from common prefixes like "[ERROR]", "WARN:",
+// or "level=debug" and uses defaultLevel for lines without detected level.
+// See LevelDetectingWriter
+func (l *Logger) NewLevelDetectingWriter(defaultLevel Level) *LevelDetectingWriter {
+	return &LevelDetectingWriter{logger: l, defaultLevel: defaultLevel}
+}
+
+// RedirectStdLog redirects the output of the standard library's
+// default log.Logger used by log.Print, log.Printf, etc.
+// to a LevelDetectingWriter of the logger with defaultLevel
+// for lines without detected level.
+// The flags of the default log.Logger are set to zero
+// because the logger adds its own timestamp.
+//
+// The returned restore function flushes the writer
+// and restores the previous output and flags of the default log.Logger.
+func (l *Logger) RedirectStdLog(defaultLevel Level) (restore func()) {
+	std := log.Default()
+	prevOutput, prevFlags := std.Writer(), std.Flags()
+	w := l.NewLevelDetectingWriter(defaultLevel)
+	std.SetOutput(w)
+	std.SetFlags(0)
+	return func() {
+		std.SetOutput(prevOutput)
+		std.SetFlags(prevFlags)
+		w.Flush()
+	}
+}
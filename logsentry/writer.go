@@ -30,7 +30,7 @@ var (
 //
 // The config determines:
 //   - Which Sentry project receives events (via hub)
-//   - How messages are formatted (via format)
+//   - How messages are formatted and values limited (via format)
 //   - Which log levels are sent to Sentry (via filter)
 //   - Whether values appear in message text (via valsAsMsg)
 //   - Additional metadata included with every event (via extra)
@@ -71,14 +71,21 @@ func NewWriterConfig(hub *sentry.Hub, format *golog.Format, filter golog.LevelFi
 	}
 }
 
+// IsActive implements the golog.LevelDecider interface
+// by returning if the filter of the config passes the level.
+func (c *WriterConfig) IsActive(ctx context.Context, level golog.Level) bool {
+	return c.filter.IsActive(ctx, level) && !IsContextWithoutLogging(ctx)
+}
+
 func (c *WriterConfig) WriterForNewMessage(ctx context.Context, level golog.Level) golog.Writer {
 	if c.filter.IsInactive(ctx, level) || IsContextWithoutLogging(ctx) {
 		return nil
 	}
-	if w, _ := c.writerPool.Get().(golog.Writer); w != nil {
-		return w
+	w, _ := c.writerPool.Get().(golog.Writer)
+	if w == nil {
+		w = &Writer{config: c}
 	}
-	return &Writer{config: c}
+	return golog.LimitWriter(w, &c.format.Limits)
 }
 
 func (c *WriterConfig) FlushUnderlying() {
@@ -281,6 +288,10 @@ func (w *Writer) WriteTime(val time.Time) {
 	w.writeVal(val)
 }
 
+func (w *Writer) WriteDuration(val time.Duration) {
+	w.writeVal(w.config.format.DurationFormat.Value(val))
+}
+
 func (w *Writer) WriteUUID(val [16]byte) {
 	w.writeVal(golog.FormatUUID(val))
 }
@@ -0,0 +1,33 @@
+/*
+Package logtest provides helpers for testing code that logs with golog.
+
+NewLogger returns a logger bound to a *testing.T that writes
+all messages to t.Log and records them with a Recorder
+for assertions:
+
+	func TestSomething(t *testing.T) {
+		log, recorder := logtest.NewLogger(t)
+
+		DoSomething(log)
+
+		recorder.AssertLogged(golog.DefaultLevels.Info, "Done", golog.NewInt("count", 3))
+		recorder.AssertNotLogged(golog.DefaultLevels.Error, "")
+		assert.Equal(t, 0, recorder.Count(golog.DefaultLevels.Warn))
+	}
+
+A Recorder can also be used as golog.WriterConfig
+in any other golog.Config.
+
+AssertGoldenText and AssertGoldenJSON compare the output
+of golog.TextWriter and golog.JSONWriter with deterministic
+timestamps and without colors against golden files in testdata/.
+The package registers a -golog.update test flag to write
+the golden files instead of comparing them.
+Setting the GOLOG_UPDATE_GOLDEN environment variable
+does the same for all packages tested with ./...
+where the flag would fail for packages not importing logtest:
+
+	go test -run TestLogFormat -golog.update
+	GOLOG_UPDATE_GOLDEN=1 go test ./...
+*/
+package logtest
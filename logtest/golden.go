@@ -0,0 +1,128 @@
+package logtest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/golog"
+)
+
+// UpdateGoldenEnv is the name of the environment variable
+// that enables updating golden files when set to a true value
+// like "1" or "true" as parsed by strconv.ParseBool.
+const UpdateGoldenEnv = "GOLOG_UPDATE_GOLDEN"
+
+// updateFlag is namespaced so that it doesn't conflict
+// with an -update flag defined by the tested package.
+var updateFlag = flag.Bool("golog.update", false, "update golden files in testdata/ instead of comparing against them")
+
+// updateGolden returns true if golden files should be written
+// instead of compared because of the -golog.update flag
+// or the UpdateGoldenEnv environment variable.
+func updateGolden() bool {
+	if *updateFlag {
+		return true
+	}
+	update, _ := strconv.ParseBool(os.Getenv(UpdateGoldenEnv))
+	return update
+}
+
+// GoldenTimestamp is the timestamp of all messages
+// logged with the context passed to the log function
+// of RenderText, RenderJSON, AssertGoldenText, and AssertGoldenJSON.
+var GoldenTimestamp = time.Date(2024, 1, 2, 3, 4, 5, 678_000_000, time.UTC)
+
+// GoldenFormat is the format used by RenderText and RenderJSON.
+// The Location is fixed to UTC so that golden files
+// don't depend on the local timezone.
+var GoldenFormat = func() *golog.Format {
+	format := golog.NewDefaultFormat()
+	format.Location = time.UTC
+	return format
+}()
+
+// LogFunc logs messages to be rendered for golden file comparison.
+// Use the Ctx methods of the logger with the passed context
+// like log.InfoCtx(ctx, "text") to get deterministic timestamps.
+type LogFunc func(ctx context.Context, log *golog.Logger)
+
+// RenderText returns the output of a golog.TextWriter without colors
+// for the messages logged by logFunc using GoldenFormat and GoldenTimestamp.
+func RenderText(logFunc LogFunc) []byte {
+	var buf bytes.Buffer
+	render(golog.NewTextWriterConfig(&buf, GoldenFormat, golog.NoColorizer), logFunc)
+	return buf.Bytes()
+}
+
+// RenderJSON returns the output of a golog.JSONWriter
+// for the messages logged by logFunc using GoldenFormat and GoldenTimestamp.
+func RenderJSON(logFunc LogFunc) []byte {
+	var buf bytes.Buffer
+	render(golog.NewJSONWriterConfig(&buf, GoldenFormat), logFunc)
+	return buf.Bytes()
+}
+
+func render(writer golog.WriterConfig, logFunc LogFunc) {
+	config := golog.NewConfig(&golog.DefaultLevels, golog.AllLevelsActive, writer)
+	ctx := golog.ContextWithTimestamp(context.Background(), GoldenTimestamp)
+	logFunc(ctx, golog.NewLogger(config))
+}
+
+// AssertGoldenText asserts that RenderText(logFunc)
+// equals the content of the golden file testdata/<name>.golden.
+// See AssertGolden.
+func AssertGoldenText(t testing.TB, name string, logFunc LogFunc) bool {
+	t.Helper()
+	return AssertGolden(t, name, RenderText(logFunc))
+}
+
+// AssertGoldenJSON asserts that RenderJSON(logFunc)
+// equals the content of the golden file testdata/<name>.golden.
+// See AssertGolden.
+func AssertGoldenJSON(t testing.TB, name string, logFunc LogFunc) bool {
+	t.Helper()
+	return AssertGolden(t, name, RenderJSON(logFunc))
+}
+
+// AssertGolden asserts that actual equals the content
+// of the golden file testdata/<name>.golden
+// relative to the directory of the tested package.
+//
+// If the tests are run with the -golog.update flag
+// or the UpdateGoldenEnv environment variable set to true,
+// then the golden file is written with actual
+// instead of being compared:
+//
+//	go test -run TestMyLogOutput -golog.update
+//	GOLOG_UPDATE_GOLDEN=1 go test ./...
+func AssertGolden(t testing.TB, name string, actual []byte) bool {
+	t.Helper()
+
+	filename := filepath.Join("testdata", name+".golden")
+	if updateGolden() {
+		err := os.MkdirAll(filepath.Dir(filename), 0o750)
+		if err == nil {
+			err = os.WriteFile(filename, actual, 0o600)
+		}
+		if err != nil {
+			t.Errorf("can't update golden file: %s", err)
+			return false
+		}
+		return true
+	}
+
+	expected, err := os.ReadFile(filename) //#nosec G304 -- filename is built from test name
+	if err != nil {
+		t.Errorf("can't read golden file, run with -golog.update to create it: %s", err)
+		return false
+	}
+	return assert.Equal(t, string(expected), string(actual), "output differs from golden file %s, run with -golog.update to update it", filename)
+}
@@ -0,0 +1,114 @@
+package logtest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/golog"
+)
+
+func logAllAttribTypes(ctx context.Context, log *golog.Logger) {
+	var (
+		timeVal = time.Date(2023, 12, 24, 18, 30, 0, 0, time.UTC)
+		uuidVal = golog.MustParseUUID("994d5800-afca-401f-9c2f-d9e3e106e9ef")
+		nilInt  *int
+	)
+	log.InfoCtx(ctx, "Scalars").
+		Nil("nil").
+		Bool("bool", true).
+		Int("int", -1).
+		Uint("uint", 1).
+		Float("float", 1.5).
+		Str("str", "Hello\tWorld!\n").
+		Err(errors.New("error")).
+		ErrorChain("errorChain", fmt.Errorf("wrapped: %w", errors.Join(errors.New("a"), errors.New("b")))).
+		Time("date", timeVal).
+		UUID("uuid", uuidVal).
+		JSON("json", []byte(`{"a":[1,2]}`)).
+		IntPtr("nilPtr", nilInt).
+		Duration("duration", 1500*time.Millisecond).
+		Lazy("lazy", func() any { return "computed" }).
+		Log()
+
+	log.DebugCtx(ctx, "Slices with one element").
+		Bools("bools", []bool{true}).
+		Ints("ints", []int{1}).
+		Uints("uints", []uint{1}).
+		Floats("floats", []float64{1.5}).
+		Strs("strs", []string{"a"}).
+		Errors("errs", []error{errors.New("a")}).
+		Times("times", []time.Time{timeVal}).
+		UUIDs("uuids", [][16]byte{uuidVal}).
+		Durations("durations", []time.Duration{time.Second}).
+		Loggable(golog.NewAnys("anys", []any{1})).
+		Log()
+
+	log.DebugCtx(ctx, "Slices with multiple elements").
+		Bools("bools", []bool{true, false}).
+		Ints("ints", []int{1, 2, 3}).
+		Uints("uints", []uint{1, 2, 3}).
+		Floats("floats", []float64{1.5, -2}).
+		Strs("strs", []string{"a", "b\"c"}).
+		Errors("errs", []error{errors.New("a"), nil}).
+		Times("times", []time.Time{timeVal, timeVal.Add(time.Hour)}).
+		UUIDs("uuids", [][16]byte{uuidVal, {}}).
+		Durations("durations", []time.Duration{time.Second, -time.Millisecond}).
+		Loggable(golog.NewAnys("anys", []any{1, "a", nil})).
+		Log()
+
+	log.TraceCtx(ctx, "Empty slices").
+		Bools("bools", nil).
+		Ints("ints", []int{}).
+		Strs("strs", nil).
+		Durations("durations", nil).
+		Loggable(golog.NewAnys("anys", []any{})).
+		Log()
+
+	log.WithPrefix("prefix").WarnCtx(ctx, "Any values").
+		Any("map", map[string]int{"b": 2, "a": 1}).
+		Any("struct", struct {
+			Name  string
+			Count int `json:"count"`
+		}{Name: "x", Count: 3}).
+		Any("anyInts", []int{1, 2}).
+		Any("nilAny", nil).
+		Any("anyDuration", time.Minute).
+		Lazy("nilLazy", nil).
+		Log()
+
+	log.With().
+		Str("sub", "attrib").
+		SubLogger().
+		ErrorCtx(ctx, "Sub logger attribs").
+		Int("own", 1).
+		Log()
+}
+
+func TestGolden(t *testing.T) {
+	AssertGoldenText(t, "allattribs.txt", logAllAttribTypes)
+	AssertGoldenJSON(t, "allattribs.json", logAllAttribTypes)
+}
+
+func TestUpdateGolden(t *testing.T) {
+	defer func(update bool) { *updateFlag = update }(*updateFlag)
+	*updateFlag = false
+
+	t.Setenv(UpdateGoldenEnv, "")
+	assert.False(t, updateGolden())
+
+	t.Setenv(UpdateGoldenEnv, "true")
+	assert.True(t, updateGolden())
+
+	t.Setenv(UpdateGoldenEnv, "0")
+	assert.False(t, updateGolden())
+
+	*updateFlag = true
+	assert.True(t, updateGolden())
+	assert.NotNil(t, flag.Lookup("golog.update"), "namespaced flag defined")
+}
@@ -0,0 +1,51 @@
+package logtest
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/domonda/golog"
+)
+
+// NewLogger returns a logger using golog.DefaultLevels
+// that writes all messages as text to t.Log
+// and records them with the also returned Recorder.
+func NewLogger(t testing.TB, filters ...golog.LevelFilter) (*golog.Logger, *Recorder) {
+	recorder := NewRecorder(t, filters...)
+	config := golog.NewConfig(
+		&golog.DefaultLevels,
+		golog.AllLevelsActive,
+		NewTestingWriterConfig(t, filters...),
+		recorder,
+	)
+	return golog.NewLogger(config), recorder
+}
+
+// NewTestingWriterConfig returns a golog.TextWriterConfig
+// without colors that writes every message to t.Log.
+//
+// Messages logged after the test completed,
+// for example by goroutines still running,
+// are ignored instead of panicking.
+func NewTestingWriterConfig(t testing.TB, filters ...golog.LevelFilter) *golog.TextWriterConfig {
+	return golog.NewTextWriterConfig(newTestingWriter(t), nil, golog.NoColorizer, filters...)
+}
+
+type testingWriter struct {
+	t    testing.TB
+	done atomic.Bool
+}
+
+func newTestingWriter(t testing.TB) *testingWriter {
+	w := &testingWriter{t: t}
+	t.Cleanup(func() { w.done.Store(true) })
+	return w
+}
+
+func (w *testingWriter) Write(p []byte) (int, error) {
+	if !w.done.Load() {
+		w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
@@ -0,0 +1,285 @@
+package logtest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/domonda/golog"
+)
+
+var _ golog.WriterConfig = new(Recorder)
+
+// Message is a log message recorded by a Recorder.
+type Message struct {
+	Timestamp time.Time
+	Level     golog.Level
+	LevelName string
+	Prefix    string
+	Text      string
+	Attribs   golog.Attribs
+}
+
+// Attrib returns the recorded attrib with the passed key or nil.
+func (m *Message) Attrib(key string) golog.Attrib {
+	return m.Attribs.Get(key)
+}
+
+// Matches returns if the message has the passed level and text
+// and all passed attribs with the same key, type, and value.
+// An empty text matches any message text.
+func (m *Message) Matches(level golog.Level, text string, attribs ...golog.Attrib) bool {
+	if m.Level != level || (text != "" && m.Text != text) {
+		return false
+	}
+	for _, expected := range attribs {
+		if !attribEqual(m.Attribs.Get(expected.Key()), expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.LevelName)
+	b.WriteByte(' ')
+	if m.Prefix != "" {
+		b.WriteString(m.Prefix)
+		b.WriteString(": ")
+	}
+	b.WriteString(m.Text)
+	for _, attrib := range m.Attribs {
+		b.WriteByte(' ')
+		b.WriteString(attrib.Key())
+		b.WriteByte('=')
+		b.WriteString(attrib.ValueString())
+	}
+	return b.String()
+}
+
+func attribEqual(actual, expected golog.Attrib) bool {
+	return actual != nil &&
+		actual.Key() == expected.Key() &&
+		reflect.TypeOf(actual) == reflect.TypeOf(expected) &&
+		actual.ValueString() == expected.ValueString()
+}
+
+// AttribValue returns the value of the attrib with the passed key
+// recorded in a message if it exists and can be converted to T
+// using golog.AttribValue.
+//
+// The value types of the golog attribs are
+// int64 for Int, uint64 for Uint, float64 for Float,
+// [16]byte for UUID, json.RawMessage for JSON,
+// and slices of those types for the slice attribs.
+// Numbers can also be retrieved as other number types
+// if the value fits, and UUIDs as formatted string.
+func AttribValue[T any](m *Message, key string) (val T, ok bool) {
+	return golog.AttribValue[T](m.Attribs, key)
+}
+
+// Recorder is a golog.WriterConfig that records all log messages
+// for inspection and assertions in tests.
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	t        testing.TB
+	filter   golog.LevelFilter
+	mutex    sync.Mutex
+	messages []Message
+	changed  chan struct{} // Closed and replaced when a message is recorded
+}
+
+// NewRecorder returns a new Recorder that uses t
+// to report failed assertions.
+func NewRecorder(t testing.TB, filters ...golog.LevelFilter) *Recorder {
+	return &Recorder{
+		t:       t,
+		filter:  golog.JoinLevelFilters(filters...),
+		changed: make(chan struct{}),
+	}
+}
+
+// IsActive implements the golog.LevelDecider interface
+// by returning if the filter of the Recorder passes the level.
+func (r *Recorder) IsActive(ctx context.Context, level golog.Level) bool {
+	return r.filter.IsActive(ctx, level)
+}
+
+func (r *Recorder) WriterForNewMessage(ctx context.Context, level golog.Level) golog.Writer {
+	if r.filter.IsInactive(ctx, level) {
+		return nil
+	}
+	w := &recordingWriter{recorder: r}
+	w.Writer = golog.NewCallbackWriterConfig(w.record).WriterForNewMessage(ctx, level)
+	return w
+}
+
+func (r *Recorder) FlushUnderlying() {}
+
+// Messages returns a copy of all recorded messages.
+func (r *Recorder) Messages() []Message {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Message(nil), r.messages...)
+}
+
+// Len returns the number of recorded messages.
+func (r *Recorder) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.messages)
+}
+
+// Count returns the number of recorded messages with the passed level.
+func (r *Recorder) Count(level golog.Level) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	count := 0
+	for i := range r.messages {
+		if r.messages[i].Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+// Reset removes all recorded messages.
+func (r *Recorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.messages = nil
+}
+
+// Find returns the first recorded message matching the passed
+// level, text, and attribs. See Message.Matches.
+func (r *Recorder) Find(level golog.Level, text string, attribs ...golog.Attrib) (msg Message, found bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.find(level, text, attribs)
+}
+
+func (r *Recorder) find(level golog.Level, text string, attribs []golog.Attrib) (msg Message, found bool) {
+	for i := range r.messages {
+		if r.messages[i].Matches(level, text, attribs...) {
+			return r.messages[i], true
+		}
+	}
+	return Message{}, false
+}
+
+// WaitFor waits until a message matching the passed level, text,
+// and attribs was recorded or the timeout expired.
+// Use it to wait for messages logged by other goroutines.
+func (r *Recorder) WaitFor(timeout time.Duration, level golog.Level, text string, attribs ...golog.Attrib) (msg Message, found bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		r.mutex.Lock()
+		msg, found = r.find(level, text, attribs)
+		changed := r.changed
+		r.mutex.Unlock()
+		if found {
+			return msg, true
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return Message{}, false
+		}
+	}
+}
+
+// AssertLogged asserts that a message matching the passed level,
+// text, and attribs was recorded. See Message.Matches.
+func (r *Recorder) AssertLogged(level golog.Level, text string, attribs ...golog.Attrib) bool {
+	r.t.Helper()
+
+	if _, found := r.Find(level, text, attribs...); !found {
+		r.t.Errorf("no log message matching %s\nrecorded messages:\n%s", r.describe(level, text, attribs), r)
+		return false
+	}
+	return true
+}
+
+// AssertNotLogged asserts that no message matching the passed level,
+// text, and attribs was recorded. See Message.Matches.
+func (r *Recorder) AssertNotLogged(level golog.Level, text string, attribs ...golog.Attrib) bool {
+	r.t.Helper()
+
+	if msg, found := r.Find(level, text, attribs...); found {
+		r.t.Errorf("unexpected log message: %s", &msg)
+		return false
+	}
+	return true
+}
+
+// AssertWaitFor asserts that a message matching the passed level,
+// text, and attribs is recorded before the timeout expires.
+func (r *Recorder) AssertWaitFor(timeout time.Duration, level golog.Level, text string, attribs ...golog.Attrib) bool {
+	r.t.Helper()
+
+	if _, found := r.WaitFor(timeout, level, text, attribs...); !found {
+		r.t.Errorf("no log message matching %s within %s\nrecorded messages:\n%s", r.describe(level, text, attribs), timeout, r)
+		return false
+	}
+	return true
+}
+
+// String returns all recorded messages, one per line.
+func (r *Recorder) String() string {
+	var b strings.Builder
+	for _, msg := range r.Messages() {
+		b.WriteString(msg.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (r *Recorder) describe(level golog.Level, text string, attribs []golog.Attrib) string {
+	return fmt.Sprintf("level=%d text=%q attribs=%s", level, text, golog.Attribs(attribs))
+}
+
+func (r *Recorder) add(msg Message) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.messages = append(r.messages, msg)
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// recordingWriter wraps a golog.CallbackWriter
+// to get the level name from the message config.
+type recordingWriter struct {
+	golog.Writer
+	recorder  *Recorder
+	levelName string
+}
+
+func (w *recordingWriter) BeginMessage(config golog.Config, timestamp time.Time, level golog.Level, prefix, text string) {
+	w.levelName = config.Levels().Name(level)
+	w.Writer.BeginMessage(config, timestamp, level, prefix, text)
+}
+
+func (w *recordingWriter) record(timestamp time.Time, level golog.Level, prefix, text string, attribs golog.Attribs) {
+	w.recorder.add(Message{
+		Timestamp: timestamp,
+		Level:     level,
+		LevelName: w.levelName,
+		Prefix:    prefix,
+		Text:      text,
+		Attribs:   attribs.Clone(),
+	})
+}
@@ -0,0 +1,77 @@
+package logtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/golog"
+)
+
+func TestRecorder(t *testing.T) {
+	log, recorder := NewLogger(t)
+	log = log.WithPrefix("test")
+
+	log.Info("Hello").Int("n", 1).Str("s", "x").Log()
+	log.Debug("Debug").Log()
+	log.Info("Hello").Int("n", 2).Log()
+
+	assert.Equal(t, 3, recorder.Len())
+	assert.Equal(t, 2, recorder.Count(golog.DefaultLevels.Info))
+	assert.Equal(t, 1, recorder.Count(golog.DefaultLevels.Debug))
+	assert.Equal(t, 0, recorder.Count(golog.DefaultLevels.Error))
+
+	recorder.AssertLogged(golog.DefaultLevels.Info, "Hello")
+	recorder.AssertLogged(golog.DefaultLevels.Info, "Hello", golog.NewInt("n", 2))
+	recorder.AssertLogged(golog.DefaultLevels.Info, "", golog.NewString("s", "x"))
+	recorder.AssertNotLogged(golog.DefaultLevels.Info, "Hello", golog.NewInt("n", 3))
+	recorder.AssertNotLogged(golog.DefaultLevels.Info, "Hello", golog.NewUint("n", 1))
+	recorder.AssertNotLogged(golog.DefaultLevels.Error, "")
+
+	msg, found := recorder.Find(golog.DefaultLevels.Info, "Hello")
+	require.True(t, found)
+	assert.Equal(t, "INFO", msg.LevelName)
+	assert.Equal(t, "test", msg.Prefix)
+	assert.Equal(t, "INFO test: Hello n=1 s=x", msg.String())
+	n, ok := AttribValue[int64](&msg, "n")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), n)
+	_, ok = AttribValue[string](&msg, "n")
+	assert.False(t, ok)
+
+	recorder.Reset()
+	assert.Equal(t, 0, recorder.Len())
+}
+
+type mockT struct {
+	testing.TB
+	failed bool
+}
+
+func (t *mockT) Helper()               {}
+func (t *mockT) Errorf(string, ...any) { t.failed = true }
+func (t *mockT) Cleanup(f func())      {}
+func (t *mockT) Log(args ...any)       {}
+
+func TestRecorder_AssertLogged_fails(t *testing.T) {
+	mock := &mockT{TB: t}
+	recorder := NewRecorder(mock)
+	assert.False(t, recorder.AssertLogged(golog.DefaultLevels.Info, "missing"))
+	assert.True(t, mock.failed)
+}
+
+func TestRecorder_WaitFor(t *testing.T) {
+	log, recorder := NewLogger(t)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		log.Warn("From goroutine").Log()
+	}()
+
+	recorder.AssertWaitFor(time.Second, golog.DefaultLevels.Warn, "From goroutine")
+
+	_, found := recorder.WaitFor(10*time.Millisecond, golog.DefaultLevels.Error, "Never")
+	assert.False(t, found)
+}
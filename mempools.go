@@ -7,31 +7,40 @@ var (
 	textWriterPool     mempool.Pointer[TextWriter]
 	jsonWriterPool     mempool.Pointer[JSONWriter]
 	callbackWriterPool mempool.Pointer[CallbackWriter]
+
+	flightRecorderWriterPool mempool.Pointer[flightRecorderWriter]
+	metricsWriterPool        mempool.Pointer[metricsWriter]
+	dedupWriterPool          mempool.Pointer[dedupWriter]
+	limitsWriterPool         mempool.Pointer[limitsWriter]
 )
 
 var (
 	attribsPool = mempool.Slice[Attrib]{
 		MinCap: 16,
 	}
-	stringPool  mempool.Pointer[String]
-	stringsPool mempool.Pointer[Strings]
-	nilPool     mempool.Pointer[Nil]
-	anyPool     mempool.Pointer[Any]
-	boolPool    mempool.Pointer[Bool]
-	boolsPool   mempool.Pointer[Bools]
-	intPool     mempool.Pointer[Int]
-	intsPool    mempool.Pointer[Ints]
-	uintPool    mempool.Pointer[Uint]
-	uintsPool   mempool.Pointer[Uints]
-	floatPool   mempool.Pointer[Float]
-	floatsPool  mempool.Pointer[Floats]
-	errorPool   mempool.Pointer[Error]
-	errorsPool  mempool.Pointer[Errors]
-	timePool    mempool.Pointer[Time]
-	timesPool   mempool.Pointer[Times]
-	uuidPool    mempool.Pointer[UUID]
-	uuidsPool   mempool.Pointer[UUIDs]
-	jsonPool    mempool.Pointer[JSON]
+	stringPool    mempool.Pointer[String]
+	stringsPool   mempool.Pointer[Strings]
+	nilPool       mempool.Pointer[Nil]
+	anyPool       mempool.Pointer[Any]
+	boolPool      mempool.Pointer[Bool]
+	boolsPool     mempool.Pointer[Bools]
+	intPool       mempool.Pointer[Int]
+	intsPool      mempool.Pointer[Ints]
+	uintPool      mempool.Pointer[Uint]
+	uintsPool     mempool.Pointer[Uints]
+	floatPool     mempool.Pointer[Float]
+	floatsPool    mempool.Pointer[Floats]
+	errorPool     mempool.Pointer[Error]
+	errorsPool    mempool.Pointer[Errors]
+	timePool      mempool.Pointer[Time]
+	timesPool     mempool.Pointer[Times]
+	durationPool  mempool.Pointer[Duration]
+	durationsPool mempool.Pointer[Durations]
+	uuidPool      mempool.Pointer[UUID]
+	uuidsPool     mempool.Pointer[UUIDs]
+	jsonPool      mempool.Pointer[JSON]
+	anysPool      mempool.Pointer[Anys]
+	lazyPool      mempool.Pointer[Lazy]
 )
 
 func DrainAllMemPools() {
@@ -39,6 +48,10 @@ func DrainAllMemPools() {
 	textWriterPool.Drain()
 	jsonWriterPool.Drain()
 	callbackWriterPool.Drain()
+	flightRecorderWriterPool.Drain()
+	metricsWriterPool.Drain()
+	dedupWriterPool.Drain()
+	limitsWriterPool.Drain()
 	attribsPool.Drain()
 	stringPool.Drain()
 	stringsPool.Drain()
@@ -56,7 +69,11 @@ func DrainAllMemPools() {
 	errorsPool.Drain()
 	timePool.Drain()
 	timesPool.Drain()
+	durationPool.Drain()
+	durationsPool.Drain()
 	uuidPool.Drain()
 	uuidsPool.Drain()
 	jsonPool.Drain()
+	anysPool.Drain()
+	lazyPool.Drain()
 }
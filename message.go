@@ -3,13 +3,16 @@ package golog
 import (
 	"bytes"
 	"context"
+	"encoding"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"go/token"
+	"log/slog"
 	"net/http"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 	"unicode/utf8"
 )
@@ -29,6 +32,13 @@ type Message struct {
 	writersArray [4]Writer // Backing store for writers slice - avoids heap allocation for ≤4 writers
 	level        Level
 	text         string // Used for LogAndPanic
+
+	namespace       string                // Prefix for the keys of added attribs
+	keys            keySet                // Keys of the recorded or written attribs
+	collision       AttribCollisionPolicy // Policy for already logged keys
+	loggerKeysOnly  bool                  // Don't track more keys for AttribCollisionLoggerWins
+	deferredWriters []Writer              // Writers for attribs recorded for AttribCollisionLastWins
+	logValuers      logValuerPath         // LogValuers resolved to the logged value by appendAnyJSON
 }
 
 func newMessage(logger *Logger, attribs Attribs, writers []Writer, level Level, text string) *Message {
@@ -43,14 +53,32 @@ func newMessage(logger *Logger, attribs Attribs, writers []Writer, level Level,
 	}
 	m.level = level
 	m.text = text
+	if logger != nil {
+		m.collision = logger.collision
+	}
+	return m
+}
+
+// newRecorderMessage returns a Message recording attribs
+// that takes ownership of the passed attribs
+// and handles their keys as already logged.
+func newRecorderMessage(logger *Logger, attribs Attribs) *Message {
+	m := newMessage(logger, attribs, nil, LevelInvalid, "")
+	for _, attrib := range attribs {
+		m.keys.add(attrib.Key())
+	}
 	return m
 }
 
 func (m *Message) reset() {
 	m.attribs.Free()
+	// Keep the memory of the keys for the next message
+	keys := m.keys
+	keys.reset()
 	// Zero the entire message (including writersArray, clearing writer refs for GC)
 	var zero Message
 	*m = zero
+	m.keys = keys
 }
 
 // IsActive returns true if the message is not nil.
@@ -82,9 +110,13 @@ func (m *Message) SubLogger() *Logger {
 	// Transfer attribs ownership to sub-logger by direct assignment
 	// (no Clone needed since message will be reset and pooled).
 	subLog := &Logger{
-		config:  m.logger.config,
-		prefix:  m.logger.prefix,
-		attribs: m.attribs,
+		config:     m.logger.config,
+		prefix:     m.logger.prefix,
+		attribs:    m.attribs,
+		source:     m.logger.source,
+		sourceSkip: m.logger.sourceSkip,
+		namespace:  m.logger.namespace,
+		collision:  m.logger.collision,
 	}
 	// Nil out attribs before reset() to prevent freeing
 	// the attribs that are now owned by subLog.
@@ -155,6 +187,30 @@ func (m *Message) Loggable(loggable Loggable) *Message {
 	return m
 }
 
+// Lazy logs the value returned by f
+// which is only called if the message is active,
+// so expensive values are not computed for messages
+// that are not written because of their level.
+// f is called once and its result is logged like with Any
+// to all writers of the message.
+//
+// Logger.With().Lazy records a Lazy attrib
+// that is computed once with the first written message
+// of the sub-logger.
+func (m *Message) Lazy(key string, f func() any) *Message {
+	if m.skipKey(&key) {
+		return m
+	}
+	if m.IsAttribRecorder() {
+		m.attribs.Add(NewLazy(key, f))
+		return m
+	}
+	if f == nil {
+		return m.writeNil(key)
+	}
+	return m.writeAny(key, f())
+}
+
 func (m *Message) Exec(logFunc func(*Message)) *Message {
 	if m == nil || logFunc == nil {
 		return m
@@ -169,15 +225,20 @@ func (m *Message) Err(val error) *Message {
 }
 
 func (m *Message) Error(key string, val error) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
+	return m.writeError(key, val)
+}
+
+// writeError logs val with a key already checked by skipKey
+func (m *Message) writeError(key string, val error) *Message {
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewError(key, val))
 		return m
 	}
 	if val == nil {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
 	for _, w := range m.writers {
 		w.WriteKey(key)
@@ -192,7 +253,7 @@ func (m *Message) Errs(vals []error) *Message {
 }
 
 func (m *Message) Errors(key string, vals []error) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -224,25 +285,64 @@ func (m *Message) CallStackSkip(key string, skip int) *Message {
 
 // Any logs val with the best matching typed log method
 // or uses Print if none was found.
+//
+// The way val is logged is resolved in the following order,
+// the checks are repeated for the value a non-nil pointer points to:
+//  1. Encoders registered with RegisterAnyEncoder
+//     or RegisterAnyWriterEncoder for the type of val
+//  2. nil and types with an IsNull() bool method returning true are logged as nil
+//  3. *ErrorChain and Source
+//  4. Loggable logs itself
+//  5. slog.LogValuer is resolved with slog.Value.Resolve
+//     which stops endless LogValue chains with an error value,
+//     the resolved value is logged by its slog.Kind
+//  6. error
+//  7. time.Duration as int64 nanoseconds or formatted with WriteDuration
+//     if the writer's Format has a DurationFormat, and UUIDs as [16]byte
+//  8. json.Marshaler as JSON
+//  9. encoding.TextMarshaler as string
+//  10. fmt.Stringer as string
+//  11. Slices and arrays element by element,
+//     other types depending on their reflect.Kind,
+//     structs and maps as JSON
+//
+// Methods of the interfaces from step 5 on are not called for nil pointers.
 func (m *Message) Any(key string, val any) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
+	return m.writeAny(key, val)
+}
+
+// writeAny logs val with a key already checked by skipKey
+func (m *Message) writeAny(key string, val any) *Message {
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewAny(key, val))
 		return m
 	}
 
 	if val == nil {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
 
 	v := reflect.ValueOf(val)
 
+	if enc, encVal := lookupAnyEncoder(v); enc != nil && enc.message != nil {
+		// The encoder logs with the methods of the message
+		// that check the key again, so unregister the key
+		// and disable the namespace already applied to it
+		m.keys.remove(key)
+		namespace := m.namespace
+		m.namespace = ""
+		enc.message(m, key, encVal.Interface())
+		m.namespace = namespace
+		return m
+	}
+
 	if isSlice(v) {
 		for _, w := range m.writers {
 			w.WriteSliceKey(key)
-			m.writeAny(w, v, false)
+			m.writeAnyValue(w, v, false)
 			w.WriteSliceEnd()
 		}
 		return m
@@ -250,31 +350,72 @@ func (m *Message) Any(key string, val any) *Message {
 
 	for _, w := range m.writers {
 		w.WriteKey(key)
-		m.writeAny(w, v, false)
+		m.writeAnyValue(w, v, false)
 	}
 	return m
 }
 
+// isSlice returns true if v or the value it points to is a slice
+// or a non UUID array that is not logged as a single value
+// because its type has a registered encoder or implements
+// one of the interfaces checked by Message.Any.
 func isSlice(v reflect.Value) bool {
 	if !v.IsValid() {
 		return false
 	}
-	for v.Kind() == reflect.Pointer && !v.IsNil() {
-		v = v.Elem()
+	elem := v
+	for elem.Kind() == reflect.Pointer && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Slice && (elem.Kind() != reflect.Array || isUUID(elem)) {
+		return false
+	}
+	for t := v.Type(); ; t = t.Elem() {
+		if isSingleValueType(t) {
+			return false
+		}
+		if t == elem.Type() {
+			return true
+		}
+	}
+}
+
+var singleValueInterfaces = []reflect.Type{
+	reflect.TypeFor[slog.LogValuer](),
+	reflect.TypeFor[error](),
+	reflect.TypeFor[json.Marshaler](),
+	reflect.TypeFor[encoding.TextMarshaler](),
+	reflect.TypeFor[fmt.Stringer](),
+}
+
+func isSingleValueType(t reflect.Type) bool {
+	if hasAnyEncoderFor(t) {
+		return true
+	}
+	for _, iface := range singleValueInterfaces {
+		if t.Implements(iface) {
+			return true
+		}
 	}
-	return v.Kind() == reflect.Slice || (v.Kind() == reflect.Array && !isUUID(v))
+	return false
 }
 
-func (m *Message) writeAny(w Writer, val reflect.Value, nestedSlice bool) {
+func (m *Message) writeAnyValue(w Writer, val reflect.Value, nestedSlice bool) {
+	// Registered encoders take precedence
+	if enc, encVal := lookupAnyEncoder(val); enc != nil && enc.writer != nil {
+		enc.writer(w, encVal.Interface())
+		return
+	}
+
 	// Try if val implements a loggable interface or is nil
 	written := m.tryWriteInterface(w, val)
 	if written {
 		return
 	}
 
-	// Deref pointers
+	// Deref pointers and interfaces like the elements of []any
 	dereferenced := false
-	for val.Kind() == reflect.Pointer && !val.IsNil() {
+	for (val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface) && !val.IsNil() {
 		val = val.Elem()
 		dereferenced = true
 	}
@@ -328,7 +469,7 @@ func (m *Message) writeAny(w Writer, val reflect.Value, nestedSlice bool) {
 			w.WriteString(fmt.Sprint(val))
 		} else {
 			for i := range val.Len() {
-				m.writeAny(w,
+				m.writeAnyValue(w,
 					val.Index(i),
 					true, // nestedSlice
 				)
@@ -341,7 +482,7 @@ func (m *Message) writeAny(w Writer, val reflect.Value, nestedSlice bool) {
 			w.WriteString(fmt.Sprint(val))
 		} else {
 			for i := range val.Len() {
-				m.writeAny(w,
+				m.writeAnyValue(w,
 					val.Index(i),
 					true, // nestedSlice
 				)
@@ -364,14 +505,38 @@ func (m *Message) tryWriteInterface(w Writer, val reflect.Value) (written bool)
 		w.WriteNil()
 		return true
 
+	case *ErrorChain:
+		writeErrorChain(w, x)
+		return true
+
+	case Source:
+		writeSource(w, x)
+		return true
+
 	case Loggable:
 		x.Log(m)
 		return true
 
+	case slog.LogValuer:
+		// Groups of the resolved value may contain x again
+		path, err := m.logValuers.push(x)
+		if err != nil {
+			w.WriteError(err)
+			return true
+		}
+		// Resolve limits the number of LogValue calls
+		// and returns an error value for cycles
+		m.writeSlogValue(w, slog.AnyValue(x).Resolve(), path)
+		return true
+
 	case error:
 		w.WriteError(x)
 		return true
 
+	case time.Duration:
+		writeAnyDuration(w, x)
+		return true
+
 	case [16]byte:
 		if IsNilUUID(x) {
 			w.WriteNil()
@@ -381,6 +546,36 @@ func (m *Message) tryWriteInterface(w Writer, val reflect.Value) (written bool)
 		return true
 	}
 
+	if val.Kind() == reflect.Pointer && val.IsNil() {
+		// Don't call methods of the following interfaces
+		// with nil pointer receivers
+		return false
+	}
+
+	switch x := val.Interface().(type) {
+	case json.Marshaler:
+		j, err := json.Marshal(x)
+		if err != nil {
+			w.WriteError(fmt.Errorf("error while marshalling %s as JSON for logging: %w", val.Type(), err))
+			return true
+		}
+		w.WriteJSON(j)
+		return true
+
+	case encoding.TextMarshaler:
+		text, err := x.MarshalText()
+		if err != nil {
+			w.WriteError(fmt.Errorf("error while marshalling %s as text for logging: %w", val.Type(), err))
+			return true
+		}
+		w.WriteString(string(text))
+		return true
+
+	case fmt.Stringer:
+		w.WriteString(x.String())
+		return true
+	}
+
 	return false
 }
 
@@ -406,6 +601,15 @@ func (m *Message) tryWriteInterface(w Writer, val reflect.Value) (written bool)
 //     "***REDACTED***" in the log output. Suppression modifiers win over
 //     redact: `json:",redact,omitempty"` on an empty string emits nothing,
 //     not the redaction marker.
+//   - flatten — log the fields of a nested struct or the entries of a
+//     map with string keys recursively with dotted keys like "address.city"
+//     instead of a JSON object. Set FlattenStructFields to flatten all
+//     nested values. MaxStructFieldsDepth limits the nesting depth, deeper
+//     values are logged as JSON, and values referencing themselves are
+//     logged as "<cycle>". Types logged as single value by Message.Any,
+//     like time.Time or types implementing fmt.Stringer, are not flattened.
+//   - inline — like flatten but without prefixing the keys with the
+//     field's key, the same way embedded structs are logged.
 //
 // Unknown modifier tokens are ignored silently, matching encoding/json's
 // forward-compat posture.
@@ -417,7 +621,7 @@ func (m *Message) StructFields(strct any) *Message {
 	if m == nil || strct == nil {
 		return m
 	}
-	m.structFields(reflect.ValueOf(strct), "golog", "log", "json")
+	m.structFields(reflect.ValueOf(strct), "golog,log,json")
 	return m
 }
 
@@ -442,105 +646,187 @@ func (m *Message) TaggedStructFields(strct any, keyTag string) *Message {
 	return m
 }
 
-func (m *Message) structFields(v reflect.Value, keyTags ...string) {
+func (m *Message) structFields(v reflect.Value, keyTags string) {
+	walk := structFieldsWalk{
+		keyTags:  keyTags,
+		recorder: m.IsAttribRecorder(), // Attrib recorders keep the Any attribs of Message.Any
+	}
+	// The root pointer is not added to walk.visited
+	// to avoid an allocation for the common case
+	// of structs without embedded or flattened pointers
 	for v.Kind() == reflect.Pointer {
 		if v.IsNil() {
 			return
 		}
+		walk.root = v.Pointer()
 		v = v.Elem()
 	}
-	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
+	m.walkStructFields(&walk, v, "", 0, FlattenStructFields)
+}
+
+// structFieldsWalk is the state of logging
+// the fields of a struct and its nested values
+type structFieldsWalk struct {
+	keyTags  string
+	recorder bool
+	root     uintptr   // pointer to the struct passed to StructFields
+	visited  []uintptr // pointers and maps of the current path for cycle detection
+}
 
-		if field.Anonymous {
-			m.structFields(v.Field(i), keyTags...)
+// walkStructFields logs the fields of the struct v
+// with prefix and a dot prepended to the keys if prefix is not empty.
+// depth is the nesting depth of flattened values
+// and flatten is true if all nested values are flattened.
+func (m *Message) walkStructFields(walk *structFieldsWalk, v reflect.Value, prefix string, depth int, flatten bool) {
+	numVisited := len(walk.visited)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() || walk.visit(v) {
+			walk.visited = walk.visited[:numVisited]
+			return
+		}
+		v = v.Elem()
+	}
+	for _, f := range structPlanFor(v.Type(), walk.keyTags) {
+		fv := v.Field(f.index)
+
+		if f.embedded {
+			m.walkStructFields(walk, fv, prefix, depth, flatten)
 			continue
 		}
-		if !token.IsExported(field.Name) {
+		if shouldOmitStructField(fv, f.structFieldDirectives) {
 			continue
 		}
-
-		// Find the first keyTag that "matches" this field. Lookup (not
-		// Get) so that an explicit empty tag value like `json:""` counts
-		// as present.
-		//
-		// An empty string in keyTags is a wildcard: it matches every
-		// exported field with an empty raw tag value (which the caller
-		// later substitutes with the Go field name). This gives callers
-		// a way to dump a struct wholesale under Go field names, e.g.
-		// `TaggedStructFields(s, "")` for log-everything, or
-		// `structFields(v, "json", "")` for "prefer json tag, fall back
-		// to Go field name".
-		var rawTag string
-		var found bool
-		for _, kt := range keyTags {
-			if kt == "" {
-				found = true
-				rawTag = ""
-				break
-			}
-			if tv, ok := field.Tag.Lookup(kt); ok {
-				rawTag = tv
-				found = true
-				break
-			}
+		key := f.key
+		if prefix != "" {
+			key = prefix + "." + f.key
 		}
-		if !found {
+		if f.redact {
+			m.Str(key, "***REDACTED***")
 			continue
 		}
-
-		d := parseStructFieldDirectives(rawTag)
-		if d.skip {
+		if f.inline && m.flattenValue(walk, fv, prefix, depth, flatten) {
 			continue
 		}
-		if d.key == "" {
-			d.key = field.Name
+		if (f.flatten || flatten) && m.flattenValue(walk, fv, key, depth+1, true) {
+			continue
 		}
-
-		fv := v.Field(i)
-		if shouldOmitStructField(fv, d) {
+		if f.write != nil && !walk.recorder && !hasAnyEncoderFor(f.fieldType) {
+			f.write(m, key, fv)
 			continue
 		}
+		m.Any(key, fv.Interface())
+	}
+	walk.visited = walk.visited[:numVisited]
+}
 
-		if d.redact {
-			m.Str(d.key, "***REDACTED***")
-			continue
+// flattenValue logs the fields of a struct or the entries of a map
+// with string keys in v with dotted keys prefixed with prefix.
+// Returns false without logging anything if v is not flattenable
+// or depth is greater than MaxStructFieldsDepth.
+// A value already visited on the current path is logged as "<cycle>".
+func (m *Message) flattenValue(walk *structFieldsWalk, v reflect.Value, prefix string, depth int, flatten bool) bool {
+	if depth > MaxStructFieldsDepth {
+		return false
+	}
+	numVisited := len(walk.visited)
+	defer func() { walk.visited = walk.visited[:numVisited] }() // Flattening is not on the hot path
+
+	for {
+		if !v.IsValid() || !isFlattenableType(v.Type()) {
+			return false
+		}
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			break
+		}
+		if v.IsNil() {
+			return false
+		}
+		if v.Kind() == reflect.Pointer && walk.visit(v) {
+			m.Str(prefix, "<cycle>")
+			return true
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m.walkStructFields(walk, v, prefix, depth, flatten)
+		return true
+
+	case reflect.Map:
+		if walk.visit(v) {
+			m.Str(prefix, "<cycle>")
+			return true
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+		for _, k := range keys {
+			key := k.String()
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			val := v.MapIndex(k)
+			if m.flattenValue(walk, val, key, depth+1, flatten) {
+				continue
+			}
+			if val.Kind() == reflect.Interface && val.IsNil() {
+				m.Nil(key)
+				continue
+			}
+			m.Any(key, val.Interface())
 		}
+		return true
+	}
+	return false
+}
 
-		m.Any(d.key, fv.Interface())
+// visit adds the pointer or map v to the visited values of the current path
+// and returns true if it was already visited.
+func (walk *structFieldsWalk) visit(v reflect.Value) (visited bool) {
+	ptr := v.Pointer()
+	if ptr == walk.root || slices.Contains(walk.visited, ptr) {
+		return true
 	}
+	walk.visited = append(walk.visited, ptr)
+	return false
 }
 
 // Print logs vals as string with the "%v" format of the fmt package.
 // If only one value is passed for vals, then it will be logged as single string,
 // else a slice of strings will be logged for vals.
+//
+// Values implementing slog.LogValuer are resolved first.
+// Then the fmt package uses the fmt.Formatter, error, or fmt.Stringer
+// implementations of a value.
+// Values implementing none of those but encoding.TextMarshaler
+// are logged as their marshalled text.
 func (m *Message) Print(key string, vals ...any) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
 		if len(vals) == 1 {
-			m.attribs.Add(NewString(key, fmt.Sprint(vals...)))
+			m.attribs.Add(NewString(key, printString(vals[0])))
 		} else {
 			strs := make([]string, len(vals))
 			for i, val := range vals {
-				strs[i] = fmt.Sprint(val)
+				strs[i] = printString(val)
 			}
 			m.attribs.Add(NewStrings(key, strs))
 		}
 		return m
 	}
 	if len(vals) == 1 {
+		str := printString(vals[0])
 		for _, w := range m.writers {
 			w.WriteKey(key)
-			w.WriteString(fmt.Sprint(vals...))
+			w.WriteString(str)
 		}
 	} else {
 		for _, w := range m.writers {
 			w.WriteSliceKey(key)
 			for _, val := range vals {
-				w.WriteString(fmt.Sprint(val))
+				w.WriteString(printString(val))
 			}
 			w.WriteSliceEnd()
 		}
@@ -548,10 +834,37 @@ func (m *Message) Print(key string, vals ...any) *Message {
 	return m
 }
 
+// printString formats val for Message.Print
+func printString(val any) string {
+	if valuer, ok := val.(slog.LogValuer); ok {
+		resolved := slog.AnyValue(valuer).Resolve()
+		if resolved.Kind() != slog.KindAny {
+			return resolved.String()
+		}
+		val = resolved.Any()
+	}
+	switch val.(type) {
+	case fmt.Formatter, error, fmt.Stringer:
+		// Handled by the fmt package
+	case encoding.TextMarshaler:
+		if v := reflect.ValueOf(val); v.Kind() != reflect.Pointer || !v.IsNil() {
+			if text, err := val.(encoding.TextMarshaler).MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+	}
+	return fmt.Sprint(val)
+}
+
 func (m *Message) Nil(key string) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
+	return m.writeNil(key)
+}
+
+// writeNil logs nil with a key already checked by skipKey
+func (m *Message) writeNil(key string) *Message {
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewNil(key))
 		return m
@@ -564,7 +877,7 @@ func (m *Message) Nil(key string) *Message {
 }
 
 func (m *Message) Bool(key string, val bool) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -586,7 +899,7 @@ func (m *Message) BoolPtr(key string, val *bool) *Message {
 }
 
 func (m *Message) Bools(key string, vals []bool) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -604,7 +917,7 @@ func (m *Message) Bools(key string, vals []bool) *Message {
 }
 
 func (m *Message) Int(key string, val int) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -626,7 +939,7 @@ func (m *Message) IntPtr(key string, val *int) *Message {
 }
 
 func (m *Message) Ints(key string, vals []int) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -644,7 +957,7 @@ func (m *Message) Ints(key string, vals []int) *Message {
 }
 
 func (m *Message) Int8(key string, val int8) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -666,7 +979,7 @@ func (m *Message) Int8Ptr(key string, val *int8) *Message {
 }
 
 func (m *Message) Int8s(key string, vals []int8) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -684,7 +997,7 @@ func (m *Message) Int8s(key string, vals []int8) *Message {
 }
 
 func (m *Message) Int16(key string, val int16) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -706,7 +1019,7 @@ func (m *Message) Int16Ptr(key string, val *int16) *Message {
 }
 
 func (m *Message) Int16s(key string, vals []int16) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -724,7 +1037,7 @@ func (m *Message) Int16s(key string, vals []int16) *Message {
 }
 
 func (m *Message) Int32(key string, val int32) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -746,7 +1059,7 @@ func (m *Message) Int32Ptr(key string, val *int32) *Message {
 }
 
 func (m *Message) Int32s(key string, vals []int32) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -764,7 +1077,7 @@ func (m *Message) Int32s(key string, vals []int32) *Message {
 }
 
 func (m *Message) Int64(key string, val int64) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -786,7 +1099,7 @@ func (m *Message) Int64Ptr(key string, val *int64) *Message {
 }
 
 func (m *Message) Int64s(key string, vals []int64) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -804,7 +1117,7 @@ func (m *Message) Int64s(key string, vals []int64) *Message {
 }
 
 func (m *Message) Uint(key string, val uint) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -826,7 +1139,7 @@ func (m *Message) UintPtr(key string, val *uint) *Message {
 }
 
 func (m *Message) Uints(key string, vals []uint) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -844,7 +1157,7 @@ func (m *Message) Uints(key string, vals []uint) *Message {
 }
 
 func (m *Message) Uint8(key string, val uint8) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -866,7 +1179,7 @@ func (m *Message) Uint8Ptr(key string, val *uint8) *Message {
 }
 
 func (m *Message) Uint8s(key string, vals []uint8) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -884,7 +1197,7 @@ func (m *Message) Uint8s(key string, vals []uint8) *Message {
 }
 
 func (m *Message) Uint16(key string, val uint16) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -906,7 +1219,7 @@ func (m *Message) Uint16Ptr(key string, val *uint16) *Message {
 }
 
 func (m *Message) Uint16s(key string, vals []uint16) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -924,7 +1237,7 @@ func (m *Message) Uint16s(key string, vals []uint16) *Message {
 }
 
 func (m *Message) Uint32(key string, val uint32) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -946,7 +1259,7 @@ func (m *Message) Uint32Ptr(key string, val *uint32) *Message {
 }
 
 func (m *Message) Uint32s(key string, vals []uint32) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -964,7 +1277,7 @@ func (m *Message) Uint32s(key string, vals []uint32) *Message {
 }
 
 func (m *Message) Uint64(key string, val uint64) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -986,7 +1299,7 @@ func (m *Message) Uint64Ptr(key string, val *uint64) *Message {
 }
 
 func (m *Message) Uint64s(key string, vals []uint64) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1004,7 +1317,7 @@ func (m *Message) Uint64s(key string, vals []uint64) *Message {
 }
 
 func (m *Message) Float32(key string, val float32) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1026,7 +1339,7 @@ func (m *Message) Float32Ptr(key string, val *float32) *Message {
 }
 
 func (m *Message) Float32s(key string, vals []float32) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1045,7 +1358,7 @@ func (m *Message) Float32s(key string, vals []float32) *Message {
 
 // Float is not called Float64 on purpose
 func (m *Message) Float(key string, val float64) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1067,7 +1380,7 @@ func (m *Message) FloatPtr(key string, val *float64) *Message {
 }
 
 func (m *Message) Floats(key string, vals []float64) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1085,9 +1398,14 @@ func (m *Message) Floats(key string, vals []float64) *Message {
 }
 
 func (m *Message) Str(key, val string) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
+	return m.writeStr(key, val)
+}
+
+// writeStr logs val with a key already checked by skipKey
+func (m *Message) writeStr(key, val string) *Message {
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewString(key, val))
 		return m
@@ -1101,6 +1419,7 @@ func (m *Message) Str(key, val string) *Message {
 
 // StrMax logs the string val with a maximum number of maxNumRunes runes.
 // If maxNumRunes is <= 0 then the string is logged as is.
+// Use Format.Limits or LimitsWriterConfig to limit the length of all logged strings.
 func (m *Message) StrMax(key, val string, maxNumRunes int) *Message {
 	if maxNumRunes <= 0 {
 		return m.Str(key, val)
@@ -1123,7 +1442,7 @@ func (m *Message) StrPtr(key string, val *string) *Message {
 }
 
 func (m *Message) Strs(key string, vals []string) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1150,11 +1469,11 @@ func (m *Message) Stringer(key string, val fmt.Stringer) *Message {
 // Time logs a time.Time formatted using the configured TimeFormat,
 // or logs nil if val.IsZero().
 func (m *Message) Time(key string, val time.Time) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if val.IsZero() {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewTime(key, val))
@@ -1178,11 +1497,11 @@ func (m *Message) TimePtr(key string, val *time.Time) *Message {
 
 // Times logs a slice of time.Time formatted using the configured TimeFormat.
 func (m *Message) Times(key string, vals []time.Time) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if len(vals) == 0 {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewTimesCopy(key, vals))
@@ -1198,23 +1517,30 @@ func (m *Message) Times(key string, vals []time.Time) *Message {
 	return m
 }
 
-// Duration logs the passed duration as string
-// using the time.Duration.String method
+// Duration logs the passed duration formatted
+// according to the DurationFormat of the writer's Format.
+// The default DurationFormatString uses the time.Duration.String method
 // representing the duration in the form "72h3m0.5s".
 // Leading zero units are omitted. As a special case, durations less than one
 // second format use a smaller unit (milli-, micro-, or nanoseconds) to ensure
 // that the leading digit is non-zero. The zero duration formats as 0s.
 func (m *Message) Duration(key string, val time.Duration) *Message {
-	return m.Str(key, val.String())
+	if m.skipKey(&key) {
+		return m
+	}
+	if m.IsAttribRecorder() {
+		m.attribs.Add(NewDuration(key, val))
+		return m
+	}
+	for _, w := range m.writers {
+		w.WriteKey(key)
+		w.WriteDuration(val)
+	}
+	return m
 }
 
-// DurationPtr logs the passed non-nil duration as string
-// using the time.Duration.String method
-// representing the duration in the form "72h3m0.5s".
-// Leading zero units are omitted. As a special case, durations less than one
-// second format use a smaller unit (milli-, micro-, or nanoseconds) to ensure
-// that the leading digit is non-zero. The zero duration formats as 0s.
-// A nil duration is logged as nil.
+// DurationPtr logs the passed non-nil duration
+// like Duration or logs nil if val is nil.
 func (m *Message) DurationPtr(key string, val *time.Duration) *Message {
 	if val == nil {
 		return m.Nil(key)
@@ -1222,6 +1548,26 @@ func (m *Message) DurationPtr(key string, val *time.Duration) *Message {
 	return m.Duration(key, *val)
 }
 
+// Durations logs a slice of durations formatted
+// according to the DurationFormat of the writer's Format.
+func (m *Message) Durations(key string, vals []time.Duration) *Message {
+	if m.skipKey(&key) {
+		return m
+	}
+	if m.IsAttribRecorder() {
+		m.attribs.Add(NewDurationsCopy(key, vals))
+		return m
+	}
+	for _, w := range m.writers {
+		w.WriteSliceKey(key)
+		for _, val := range vals {
+			w.WriteDuration(val)
+		}
+		w.WriteSliceEnd()
+	}
+	return m
+}
+
 // Millis logs the passed duration as millisecond integer.
 func (m *Message) Millis(key string, val time.Duration) *Message {
 	return m.Int64(key, val.Milliseconds())
@@ -1245,7 +1591,7 @@ func (m *Message) MicrosSince(key string, t time.Time) *Message {
 // UUID logs a UUID or nil in case of a "Nil UUID" containing only zero bytes.
 // See IsNilUUID.
 func (m *Message) UUID(key string, val [16]byte) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1278,7 +1624,7 @@ func (m *Message) UUIDPtr(key string, val *[16]byte) *Message {
 // UUID logs a slice of UUIDs using nil in case of a "Nil UUID" containing only zero bytes.
 // See IsNilUUID.
 func (m *Message) UUIDs(key string, vals [][16]byte) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if m.IsAttribRecorder() {
@@ -1301,11 +1647,11 @@ func (m *Message) UUIDs(key string, vals [][16]byte) *Message {
 
 // JSON logs JSON encoded bytes
 func (m *Message) JSON(key string, val []byte) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if val == nil {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
 	valCpy := bytes.NewBuffer(make([]byte, 0, len(val)))
 	err := json.Compact(valCpy, val)
@@ -1330,12 +1676,12 @@ func (m *Message) JSON(key string, val []byte) *Message {
 
 // AsJSON logs the JSON marshaled val.
 func (m *Message) AsJSON(key string, val any) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	jsonVal, err := json.Marshal(val)
 	if err != nil {
-		return m.Error(key, fmt.Errorf("can't log %T AsJSON because of: %w", val, err))
+		return m.writeError(key, fmt.Errorf("can't log %T AsJSON because of: %w", val, err))
 	}
 	if m.IsAttribRecorder() {
 		m.attribs.Add(NewJSON(key, jsonVal))
@@ -1350,28 +1696,28 @@ func (m *Message) AsJSON(key string, val any) *Message {
 
 // Bytes logs binary data as string encoded using base64.RawURLEncoding
 func (m *Message) Bytes(key string, val []byte) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if val == nil {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
-	return m.Str(key, base64.RawURLEncoding.EncodeToString(val))
+	return m.writeStr(key, base64.RawURLEncoding.EncodeToString(val))
 }
 
 // StrBytes logs the passed bytes as string if they are valid UTF-8,
 // else the bytes are encoded using base64.RawURLEncoding.
 func (m *Message) StrBytes(key string, val []byte) *Message {
-	if m == nil || m.attribs.Has(key) {
+	if m.skipKey(&key) {
 		return m
 	}
 	if val == nil {
-		return m.Nil(key)
+		return m.writeNil(key)
 	}
 	if !utf8.Valid(val) {
-		return m.Str(key, base64.RawURLEncoding.EncodeToString(val))
+		return m.writeStr(key, base64.RawURLEncoding.EncodeToString(val))
 	}
-	return m.Str(key, string(val))
+	return m.writeStr(key, string(val))
 }
 
 // StrBytesMax logs the passed bytes as string if they are valid UTF-8,
@@ -1458,6 +1804,9 @@ func (m *Message) Log() {
 		return
 	}
 
+	if m.deferredWriters != nil {
+		m.writeRecordedAttribs()
+	}
 	for _, w := range m.writers {
 		w.CommitMessage()
 	}
@@ -0,0 +1,167 @@
+package golog
+
+import (
+	"bytes"
+	"log/slog"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testColor int
+
+func (c testColor) String() string { return [...]string{"red", "green"}[c] }
+
+type testSecret string
+
+func (testSecret) LogValue() slog.Value { return slog.StringValue("***") }
+
+type testUser struct {
+	Name string
+	Age  int
+}
+
+func (u testUser) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", u.Name), slog.Int("age", u.Age), slog.Duration("since", time.Minute))
+}
+
+type testCycle struct{}
+
+func (c testCycle) LogValue() slog.Value { return slog.AnyValue(c) }
+
+type testGroupCycle struct{ ID int }
+
+func (c testGroupCycle) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("id", c.ID), slog.Any("self", c))
+}
+
+type testGroupNested struct{ Depth int }
+
+func (n testGroupNested) LogValue() slog.Value {
+	return slog.GroupValue(slog.Any("next", testGroupNested{n.Depth + 1}))
+}
+
+type testLevelText struct{ level string }
+
+func (l testLevelText) MarshalText() ([]byte, error) { return []byte("level-" + l.level), nil }
+
+func TestMessage_Any_interfaces(t *testing.T) {
+	var textBuf, jsonBuf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewTextWriterConfig(&textBuf, &Format{}, NoColorizer),
+		NewJSONWriterConfig(&jsonBuf, &Format{}),
+	))
+	var nilColor *testColor
+
+	log.Info("Interfaces").
+		Any("ip", net.ParseIP("192.168.0.1")).
+		Any("big", big.NewInt(1234567890123)).
+		Any("color", testColor(1)).
+		Any("colors", []testColor{0, 1}).
+		Any("nilColor", nilColor).
+		Any("secret", testSecret("password")).
+		Any("user", testUser{Name: "Alice", Age: 42}).
+		Any("cycle", testCycle{}).
+		Any("text", testLevelText{"debug"}).
+		Log()
+
+	assert.Equal(t, `{`+
+		`"ip":"192.168.0.1",`+
+		`"big":1234567890123,`+
+		`"color":"green",`+
+		`"colors":["red","green"],`+
+		`"nilColor":null,`+
+		`"secret":"***",`+
+		`"user":{"name":"Alice","age":42,"since":60000000000},`+
+		`"cycle":"LogValue called too many times on Value of type golog.testCycle",`+
+		`"text":"level-debug"`+
+		"}\n",
+		jsonBuf.String(),
+	)
+	assert.Equal(t, ` |INFO | Interfaces ip="192.168.0.1" big=1234567890123 color="green" colors=["red","green"] nilColor=nil secret="***" user={"name":"Alice","age":42,"since":60000000000} cycle=`+"`LogValue called too many times on Value of type golog.testCycle`"+` text="level-debug"`+"\n", textBuf.String())
+}
+
+func TestMessage_Any_LogValuerGroupFormat(t *testing.T) {
+	// Values of slog groups use the Format and Limits of the writer
+	var textBuf, jsonBuf, limitsBuf bytes.Buffer
+	format := &Format{
+		DurationFormat: DurationFormatISO8601,
+		Limits:         Limits{MaxStringLength: 3},
+	}
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewTextWriterConfig(&textBuf, format, NoColorizer),
+		NewJSONWriterConfig(&jsonBuf, format),
+		NewLimitsWriterConfig(Limits{MaxStringLength: 2}, NewJSONWriterConfig(&limitsBuf, &Format{DurationFormat: DurationFormatSeconds})),
+	))
+
+	log.Info("").Any("user", testUser{Name: "Alice", Age: 42}).Log()
+
+	assert.Equal(t, ` |INFO | user={"name":"Ali...","age":42,"since":"PT1M"} truncated=true`+"\n", textBuf.String())
+	assert.Equal(t, `{"user":{"name":"Ali...","age":42,"since":"PT1M"},"truncated":true}`+"\n", jsonBuf.String())
+	assert.Equal(t, `{"user":{"name":"Al...","age":42,"since":60},"truncated":true}`+"\n", limitsBuf.String())
+}
+
+func TestMessage_Print_interfaces(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer)))
+
+	log.Info("Print").
+		Print("secret", testSecret("password")).
+		Print("color", testColor(0)).
+		Print("text", testLevelText{"info"}).
+		Print("values", testSecret("x"), testLevelText{"warn"}, 1).
+		Log()
+
+	assert.Equal(t, ` |INFO | Print secret="***" color="red" text="level-info" values=["***","level-warn","1"]`+"\n", buf.String())
+}
+
+func TestAny_AppendJSON_interfaces(t *testing.T) {
+	// Any attribs render the same JSON as Message.Any with a JSONWriter
+	for _, tt := range []struct {
+		val  any
+		want string
+	}{
+		{net.ParseIP("10.0.0.1"), `"ip":"10.0.0.1"`},
+		{testColor(1), `"ip":"green"`},
+		{[]testColor{1, 0}, `"ip":["green","red"]`},
+		{testSecret("password"), `"ip":"***"`},
+		{testUser{Name: "Bob", Age: 7}, `"ip":{"name":"Bob","age":7,"since":60000000000}`},
+		{struct{ A int }{A: 1}, `"ip":{"A":1}`},
+		{time.Second, `"ip":1000000000`},
+	} {
+		a := NewAny("ip", tt.val)
+		assert.Equal(t, "{"+tt.want, string(a.AppendJSON([]byte("{"))))
+		assert.Equal(t, `{"x":1,`+tt.want, string(a.AppendJSON([]byte(`{"x":1`))), "comma separator")
+		a.Free()
+	}
+}
+
+func TestMessage_StructFields_interfaces(t *testing.T) {
+	type S struct {
+		Color  testColor  `json:"color"`
+		Secret testSecret `json:"secret"`
+		IP     net.IP     `json:"ip"`
+	}
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})))
+
+	log.Info("").StructFields(S{Color: 1, Secret: "x", IP: net.IPv4(1, 2, 3, 4)}).Log()
+
+	assert.Equal(t, `{"color":"green","secret":"***","ip":"1.2.3.4"}`+"\n", buf.String())
+}
+
+func TestMessage_Any_LogValuerGroupCycle(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})))
+
+	log.Info("").
+		Any("cycle", testGroupCycle{ID: 1}).
+		Any("nested", testGroupNested{}).
+		Log()
+
+	assert.Contains(t, buf.String(), `"cycle":{"id":1,"self":"LogValue cycle for Value of type golog.testGroupCycle"}`)
+	assert.Contains(t, buf.String(), `"next":"LogValue nested deeper than 16 for Value of type golog.testGroupNested"`)
+}
@@ -189,46 +189,22 @@ Returned *golog.Message
 Allocated *golog.TextWriter
 Allocated *golog.JSONWriter
 Reused *golog.Message
-Allocated []golog.Attrib len:3 cap:16
-Allocated *golog.String
-Allocated *golog.Strings
-Allocated *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 1:
 Reused *golog.TextWriter
 Reused *golog.JSONWriter
 Reused *golog.Message
-Reused []golog.Attrib len:3 cap:3
-Reused *golog.String
-Reused *golog.Strings
-Reused *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 2:
 Reused *golog.TextWriter
 Reused *golog.JSONWriter
 Reused *golog.Message
-Reused []golog.Attrib len:3 cap:3
-Reused *golog.String
-Reused *golog.Strings
-Reused *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 RemoveAttribs:
 Returned *golog.String
@@ -298,35 +274,15 @@ Returned *golog.Message
 Allocated *golog.TextWriter
 Allocated *golog.JSONWriter
 Reused *golog.Message
-Allocated []golog.Attrib len:4 cap:16
-Allocated *golog.UUID
-Allocated *golog.String
-Allocated *golog.Strings
-Allocated *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.UUID
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 1:
 Reused *golog.TextWriter
 Reused *golog.JSONWriter
 Reused *golog.Message
-Reused []golog.Attrib len:4 cap:4
-Reused *golog.UUID
-Reused *golog.String
-Reused *golog.Strings
-Reused *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.UUID
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 RemoveAttribs:
 Returned *golog.UUID
@@ -393,46 +349,22 @@ Returned *golog.Message
 Allocated *golog.TextWriter
 Allocated *golog.JSONWriter
 Reused *golog.Message
-Allocated []golog.Attrib len:3 cap:16
-Allocated *golog.String
-Allocated *golog.Strings
-Allocated *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 1:
 Reused *golog.TextWriter
 Reused *golog.JSONWriter
 Reused *golog.Message
-Reused []golog.Attrib len:3 cap:3
-Reused *golog.String
-Reused *golog.Strings
-Reused *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 2:
 Reused *golog.TextWriter
 Reused *golog.JSONWriter
 Reused *golog.Message
-Reused []golog.Attrib len:3 cap:3
-Reused *golog.String
-Reused *golog.Strings
-Reused *golog.Nil
 Returned *golog.TextWriter
 Returned *golog.JSONWriter
-Returned *golog.String
-Returned *golog.Strings
-Returned *golog.Nil
-Returned []golog.Attrib
 Returned *golog.Message
 RemoveAttribs:
 Returned *golog.String
@@ -0,0 +1,226 @@
+package golog
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	_ WriterConfig      = new(MetricsWriterConfig)
+	_ Writer            = new(metricsWriter)
+	_ ErrorChainWriter  = new(metricsWriter)
+	_ SourceWriter      = new(metricsWriter)
+	_ CommitErrorWriter = new(metricsWriter)
+)
+
+// Metrics receives the counts and latencies of messages
+// written by the WriterConfigs wrapped with MetricsWriterConfig.
+//
+// Implementations must be safe for concurrent use.
+// MetricsCollector is an implementation that can be
+// exposed in the Prometheus text format.
+type Metrics interface {
+	// MessageWritten is called after a writer committed
+	// a message with the time it took to commit the message.
+	// It is also called if writing the message failed.
+	MessageWritten(writer string, level Level, prefix string, latency time.Duration)
+
+	// MessageDropped is called when a writer config
+	// filtered out a message, for example because of
+	// its level filter or a wrapper deciding not to write it.
+	MessageDropped(writer string, level Level)
+
+	// WriteError is called when a writer failed to write a message.
+	WriteError(writer string, level Level, err error)
+}
+
+// CommitErrorWriter can be implemented by a Writer
+// to return the error of committing a message
+// instead of passing it to ErrorHandler
+// so that MetricsWriterConfig can count write errors.
+type CommitErrorWriter interface {
+	// CommitMessageError commits the message like CommitMessage
+	// but returns the error of writing it instead of passing it to ErrorHandler.
+	CommitMessageError() error
+}
+
+// MetricsWriterConfig wraps a WriterConfig and reports
+// the messages written and dropped by it, the latency of committing messages,
+// and write errors to a Metrics implementation under the name of the writer.
+//
+// Write errors are only reported for Writers implementing CommitErrorWriter
+// like TextWriter and JSONWriter, they are still passed to ErrorHandler.
+//
+// Example:
+//
+//	metrics := golog.NewMetricsCollector(&golog.DefaultLevels)
+//	config := golog.NewConfig(
+//		&golog.DefaultLevels,
+//		golog.AllLevelsActive,
+//		golog.NewMetricsWriterConfig(metrics, "stdout", golog.NewJSONWriterConfig(os.Stdout, nil)),
+//	)
+//	http.Handle("/metrics", metrics)
+type MetricsWriterConfig struct {
+	metrics Metrics
+	name    string
+	wrapped WriterConfig
+}
+
+// NewMetricsWriterConfig returns a MetricsWriterConfig reporting
+// the messages of wrapped to metrics using name as writer name.
+// Panics if metrics or wrapped are nil.
+func NewMetricsWriterConfig(metrics Metrics, name string, wrapped WriterConfig) *MetricsWriterConfig {
+	if metrics == nil {
+		panic("golog.MetricsWriterConfig needs Metrics") // Panic during setup is acceptable
+	}
+	if wrapped == nil {
+		panic("golog.MetricsWriterConfig needs a WriterConfig to wrap") // Panic during setup is acceptable
+	}
+	return &MetricsWriterConfig{
+		metrics: metrics,
+		name:    name,
+		wrapped: wrapped,
+	}
+}
+
+// IsActive implements the LevelDecider interface
+// by asking the wrapped WriterConfig.
+func (c *MetricsWriterConfig) IsActive(ctx context.Context, level Level) bool {
+	return WriterConfigIsActive(ctx, c.wrapped, level)
+}
+
+func (c *MetricsWriterConfig) WriterForNewMessage(ctx context.Context, level Level) Writer {
+	wrapped := c.wrapped.WriterForNewMessage(ctx, level)
+	if wrapped == nil {
+		c.metrics.MessageDropped(c.name, level)
+		return nil
+	}
+	w := metricsWriterPool.GetOrNew()
+	w.config = c
+	w.wrapped = wrapped
+	w.level = level
+	return w
+}
+
+func (c *MetricsWriterConfig) FlushUnderlying() {
+	c.wrapped.FlushUnderlying()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type metricsWriter struct {
+	config  *MetricsWriterConfig
+	wrapped Writer
+	level   Level
+	prefix  string
+}
+
+func (w *metricsWriter) BeginMessage(config Config, timestamp time.Time, level Level, prefix, text string) {
+	w.prefix = prefix
+	w.wrapped.BeginMessage(config, timestamp, level, prefix, text)
+}
+
+func (w *metricsWriter) CommitMessage() {
+	if err := w.CommitMessageError(); err != nil && ErrorHandler != nil {
+		ErrorHandler(err)
+	}
+}
+
+func (w *metricsWriter) CommitMessageError() (err error) {
+	start := time.Now()
+	if cw, ok := w.wrapped.(CommitErrorWriter); ok {
+		err = cw.CommitMessageError()
+	} else {
+		w.wrapped.CommitMessage()
+	}
+	latency := time.Since(start)
+
+	metrics, name := w.config.metrics, w.config.name
+	metrics.MessageWritten(name, w.level, w.prefix, latency)
+	if err != nil {
+		metrics.WriteError(name, w.level, err)
+	}
+
+	// Reset and return to pool
+	*w = metricsWriter{}
+	metricsWriterPool.PutBack(w)
+	return err
+}
+
+func (w *metricsWriter) String() string {
+	return w.wrapped.String()
+}
+
+func (w *metricsWriter) WriteKey(key string) {
+	w.wrapped.WriteKey(key)
+}
+
+func (w *metricsWriter) WriteSliceKey(key string) {
+	w.wrapped.WriteSliceKey(key)
+}
+
+func (w *metricsWriter) WriteSliceEnd() {
+	w.wrapped.WriteSliceEnd()
+}
+
+func (w *metricsWriter) WriteNil() {
+	w.wrapped.WriteNil()
+}
+
+func (w *metricsWriter) WriteBool(val bool) {
+	w.wrapped.WriteBool(val)
+}
+
+func (w *metricsWriter) WriteInt(val int64) {
+	w.wrapped.WriteInt(val)
+}
+
+func (w *metricsWriter) WriteUint(val uint64) {
+	w.wrapped.WriteUint(val)
+}
+
+func (w *metricsWriter) WriteFloat(val float64) {
+	w.wrapped.WriteFloat(val)
+}
+
+func (w *metricsWriter) WriteString(val string) {
+	w.wrapped.WriteString(val)
+}
+
+func (w *metricsWriter) WriteError(val error) {
+	w.wrapped.WriteError(val)
+}
+
+func (w *metricsWriter) WriteTime(val time.Time) {
+	w.wrapped.WriteTime(val)
+}
+
+func (w *metricsWriter) WriteDuration(val time.Duration) {
+	w.wrapped.WriteDuration(val)
+}
+
+func (w *metricsWriter) WriteUUID(val [16]byte) {
+	w.wrapped.WriteUUID(val)
+}
+
+func (w *metricsWriter) WriteJSON(val []byte) {
+	w.wrapped.WriteJSON(val)
+}
+
+func (w *metricsWriter) WriteErrorChain(chain *ErrorChain) {
+	writeErrorChain(w.wrapped, chain)
+}
+
+func (w *metricsWriter) WriteSource(source Source) {
+	writeSource(w.wrapped, source)
+}
+
+func (w *metricsWriter) anyJSONConfig(config *anyJSONConfig) {
+	if c, ok := w.wrapped.(anyJSONConfigWriter); ok {
+		c.anyJSONConfig(config)
+	}
+}
+
+func (w *metricsWriter) writeAnyDuration(val time.Duration) {
+	writeAnyDuration(w.wrapped, val)
+}
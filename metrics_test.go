@@ -0,0 +1,130 @@
+package golog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestMetricsWriterConfig(t *testing.T) {
+	var handledErrs []error
+	prevErrorHandler := ErrorHandler
+	ErrorHandler = func(err error) { handledErrs = append(handledErrs, err) }
+	t.Cleanup(func() { ErrorHandler = prevErrorHandler })
+
+	var buf bytes.Buffer
+	metrics := NewMetricsCollector(&DefaultLevels)
+	config := NewConfig(&DefaultLevels, AllLevelsActive,
+		NewMetricsWriterConfig(metrics, "stdout", NewTextWriterConfig(&buf, &Format{PrefixFmt: "%s: %s"}, NoColorizer, DefaultLevels.Info.FilterOutBelow())),
+		NewMetricsWriterConfig(metrics, "file", NewJSONWriterConfig(failingWriter{}, nil)),
+	)
+	log := NewLogger(config)
+
+	log.Info("Hello").Int("n", 1).Log()
+	log.WithPrefix("db").Error("Failed").Err(errors.New("timeout")).Log()
+	log.Debug("Details").Log()
+
+	assert.Equal(t, " |INFO | Hello n=1\n |ERROR| db: Failed error=`timeout`\n", buf.String())
+	assert.Equal(t, uint64(1), metrics.MessageCount("stdout", DefaultLevels.Info, ""))
+	assert.Equal(t, uint64(1), metrics.MessageCount("stdout", DefaultLevels.Error, "db"))
+	assert.Equal(t, uint64(0), metrics.MessageCount("stdout", DefaultLevels.Debug, ""))
+	assert.Equal(t, uint64(1), metrics.DroppedCount("stdout", DefaultLevels.Debug))
+	assert.Equal(t, uint64(1), metrics.MessageCount("file", DefaultLevels.Debug, ""))
+	assert.Equal(t, uint64(3), metrics.WriteErrorCount("file"))
+	assert.Equal(t, uint64(0), metrics.WriteErrorCount("stdout"))
+
+	require.Len(t, handledErrs, 3, "write errors are still passed to ErrorHandler")
+	assert.EqualError(t, handledErrs[0], "golog.JSONWriter error: disk full")
+}
+
+func TestMetricsWriterConfig_durationFormat(t *testing.T) {
+	var buf bytes.Buffer
+	metrics := NewMetricsCollector(&DefaultLevels)
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive,
+		NewMetricsWriterConfig(metrics, "json", NewJSONWriterConfig(&buf, &Format{DurationFormat: DurationFormatISO8601})),
+	))
+
+	log.Info("").Any("any", 90*time.Second).Log()
+	assert.Equal(t, `{"any":"PT1M30S"}`+"\n", buf.String())
+}
+
+func TestMetricsCollector_ServeHTTP(t *testing.T) {
+	metrics := NewMetricsCollector(&DefaultLevels)
+	metrics.MessageWritten("stdout", DefaultLevels.Info, "", 20*time.Microsecond)
+	metrics.MessageWritten("stdout", DefaultLevels.Error, `"quoted"`, 2*time.Second)
+	metrics.MessageWritten("stdout", DefaultLevels.Info, "", 50*time.Microsecond)
+	metrics.MessageDropped("stdout", DefaultLevels.Debug)
+	metrics.WriteError("stdout", DefaultLevels.Error, errors.New("disk full"))
+
+	response := httptest.NewRecorder()
+	metrics.ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", response.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP golog_messages_total Number of log messages written by writer, level, and prefix.
+# TYPE golog_messages_total counter
+golog_messages_total{writer="stdout",level="INFO",prefix=""} 2
+golog_messages_total{writer="stdout",level="ERROR",prefix="\"quoted\""} 1
+# HELP golog_messages_dropped_total Number of log messages filtered out by writer and level.
+# TYPE golog_messages_dropped_total counter
+golog_messages_dropped_total{writer="stdout",level="DEBUG"} 1
+# HELP golog_write_errors_total Number of errors writing log messages by writer.
+# TYPE golog_write_errors_total counter
+golog_write_errors_total{writer="stdout"} 1
+# HELP golog_commit_duration_seconds Latency of committing log messages by writer.
+# TYPE golog_commit_duration_seconds histogram
+golog_commit_duration_seconds_bucket{writer="stdout",le="1e-05"} 0
+golog_commit_duration_seconds_bucket{writer="stdout",le="5e-05"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.0001"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.0005"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.001"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.005"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.01"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.05"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.1"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="0.5"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="1"} 2
+golog_commit_duration_seconds_bucket{writer="stdout",le="+Inf"} 3
+golog_commit_duration_seconds_sum{writer="stdout"} 2.00007
+golog_commit_duration_seconds_count{writer="stdout"} 3
+`, response.Body.String())
+}
+
+func TestMetricsCollector_concurrent(t *testing.T) {
+	metrics := NewMetricsCollector(&DefaultLevels)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				metrics.MessageWritten("stdout", DefaultLevels.Info, "", time.Millisecond)
+				metrics.MessageDropped("stdout", DefaultLevels.Debug)
+				metrics.WriteError("stdout", DefaultLevels.Info, errors.New("disk full"))
+				_, _ = metrics.WriteTo(io.Discard)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(8000), metrics.MessageCount("stdout", DefaultLevels.Info, ""))
+	assert.Equal(t, uint64(8000), metrics.DroppedCount("stdout", DefaultLevels.Debug))
+	assert.Equal(t, uint64(8000), metrics.WriteErrorCount("stdout"))
+
+	var buf bytes.Buffer
+	_, err := metrics.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `golog_commit_duration_seconds_bucket{writer="stdout",le="+Inf"} 8000`+"\n")
+	assert.Contains(t, buf.String(), `golog_commit_duration_seconds_sum{writer="stdout"} 8`+"\n")
+}
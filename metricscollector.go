@@ -0,0 +1,256 @@
+package golog
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	_ Metrics      = new(MetricsCollector)
+	_ http.Handler = new(MetricsCollector)
+)
+
+// DefaultMetricsLatencyBuckets are the upper bounds in seconds
+// of the commit latency histogram buckets used by NewMetricsCollector.
+var DefaultMetricsLatencyBuckets = []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1}
+
+// MetricsCollector implements Metrics by counting messages in memory.
+// It implements http.Handler to serve the metrics
+// in the Prometheus text exposition format without external dependencies:
+//
+//	golog_messages_total{writer,level,prefix}           counter
+//	golog_messages_dropped_total{writer,level}          counter
+//	golog_write_errors_total{writer}                    counter
+//	golog_commit_duration_seconds{writer}               histogram
+//
+// The counters are updated atomically without locking
+// so that committing messages from many goroutines doesn't contend.
+// A scrape reads the counters while they may be updated,
+// so the samples of one scrape are not an atomic snapshot.
+type MetricsCollector struct {
+	levels  *Levels
+	buckets []float64
+
+	messages sync.Map // map[metricsMessageKey]*atomic.Uint64
+	dropped  sync.Map // map[metricsLevelKey]*atomic.Uint64
+	errors   sync.Map // map[string]*atomic.Uint64
+	latency  sync.Map // map[string]*metricsHistogram
+}
+
+type metricsMessageKey struct {
+	writer string
+	level  Level
+	prefix string
+}
+
+type metricsLevelKey struct {
+	writer string
+	level  Level
+}
+
+type metricsHistogram struct {
+	counts   []atomic.Uint64 // per bucket plus +Inf, not cumulative
+	sumNanos atomic.Int64
+}
+
+// metricsCounter returns the counter for key in counters
+// and adds a new counter for the first use of key.
+func metricsCounter[K comparable](counters *sync.Map, key K) *atomic.Uint64 {
+	if c, ok := counters.Load(key); ok {
+		return c.(*atomic.Uint64)
+	}
+	c, _ := counters.LoadOrStore(key, new(atomic.Uint64))
+	return c.(*atomic.Uint64)
+}
+
+// metricsCount returns the value of the counter
+// for key in counters or zero if there is none.
+func metricsCount[K comparable](counters *sync.Map, key K) uint64 {
+	if c, ok := counters.Load(key); ok {
+		return c.(*atomic.Uint64).Load()
+	}
+	return 0
+}
+
+// sortedMetricsKeys returns the keys of counters sorted by compare.
+func sortedMetricsKeys[K comparable](counters *sync.Map, compare func(a, b K) int) []K {
+	var keys []K
+	counters.Range(func(key, _ any) bool {
+		keys = append(keys, key.(K))
+		return true
+	})
+	slices.SortFunc(keys, compare)
+	return keys
+}
+
+// NewMetricsCollector returns a MetricsCollector
+// using levels for the level names of the metrics
+// and DefaultMetricsLatencyBuckets for the commit latency histogram.
+func NewMetricsCollector(levels *Levels) *MetricsCollector {
+	if levels == nil {
+		panic("golog.MetricsCollector needs Levels") // Panic during setup is acceptable
+	}
+	return &MetricsCollector{
+		levels:  levels,
+		buckets: slices.Clone(DefaultMetricsLatencyBuckets),
+	}
+}
+
+func (c *MetricsCollector) MessageWritten(writer string, level Level, prefix string, latency time.Duration) {
+	metricsCounter(&c.messages, metricsMessageKey{writer, level, prefix}).Add(1)
+
+	v, ok := c.latency.Load(writer)
+	if !ok {
+		v, _ = c.latency.LoadOrStore(writer, &metricsHistogram{counts: make([]atomic.Uint64, len(c.buckets)+1)})
+	}
+	h := v.(*metricsHistogram)
+	i, _ := slices.BinarySearch(c.buckets, latency.Seconds())
+	h.counts[i].Add(1)
+	h.sumNanos.Add(int64(latency))
+}
+
+func (c *MetricsCollector) MessageDropped(writer string, level Level) {
+	metricsCounter(&c.dropped, metricsLevelKey{writer, level}).Add(1)
+}
+
+func (c *MetricsCollector) WriteError(writer string, level Level, err error) {
+	metricsCounter(&c.errors, writer).Add(1)
+}
+
+// MessageCount returns the number of messages
+// written by writer with level and prefix.
+func (c *MetricsCollector) MessageCount(writer string, level Level, prefix string) uint64 {
+	return metricsCount(&c.messages, metricsMessageKey{writer, level, prefix})
+}
+
+// DroppedCount returns the number of messages
+// with level dropped by writer.
+func (c *MetricsCollector) DroppedCount(writer string, level Level) uint64 {
+	return metricsCount(&c.dropped, metricsLevelKey{writer, level})
+}
+
+// WriteErrorCount returns the number of write errors of writer.
+func (c *MetricsCollector) WriteErrorCount(writer string) uint64 {
+	return metricsCount(&c.errors, writer)
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (c *MetricsCollector) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	response.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = c.WriteTo(response)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format
+// sorted by writer, level, and prefix.
+func (c *MetricsCollector) WriteTo(writer io.Writer) (n int64, err error) {
+	w := &metricsTextWriter{writer: bufio.NewWriter(writer)}
+
+	w.header("golog_messages_total", "counter", "Number of log messages written by writer, level, and prefix.")
+	messageKeys := sortedMetricsKeys(&c.messages, func(a, b metricsMessageKey) int {
+		return cmp.Or(
+			cmp.Compare(a.writer, b.writer),
+			cmp.Compare(a.level, b.level),
+			cmp.Compare(a.prefix, b.prefix),
+		)
+	})
+	for _, key := range messageKeys {
+		writeMetricsSample(w, "golog_messages_total", metricsCount(&c.messages, key), "writer", key.writer, "level", c.levels.Name(key.level), "prefix", key.prefix)
+	}
+
+	w.header("golog_messages_dropped_total", "counter", "Number of log messages filtered out by writer and level.")
+	droppedKeys := sortedMetricsKeys(&c.dropped, func(a, b metricsLevelKey) int {
+		return cmp.Or(
+			cmp.Compare(a.writer, b.writer),
+			cmp.Compare(a.level, b.level),
+		)
+	})
+	for _, key := range droppedKeys {
+		writeMetricsSample(w, "golog_messages_dropped_total", metricsCount(&c.dropped, key), "writer", key.writer, "level", c.levels.Name(key.level))
+	}
+
+	w.header("golog_write_errors_total", "counter", "Number of errors writing log messages by writer.")
+	for _, writer := range sortedMetricsKeys(&c.errors, strings.Compare) {
+		writeMetricsSample(w, "golog_write_errors_total", metricsCount(&c.errors, writer), "writer", writer)
+	}
+
+	w.header("golog_commit_duration_seconds", "histogram", "Latency of committing log messages by writer.")
+	for _, writer := range sortedMetricsKeys(&c.latency, strings.Compare) {
+		v, _ := c.latency.Load(writer)
+		h := v.(*metricsHistogram)
+		// The count is the sum of the loaded bucket counts
+		// so that it is consistent with the +Inf bucket
+		var cumulative uint64
+		for i, bound := range c.buckets {
+			cumulative += h.counts[i].Load()
+			writeMetricsSample(w, "golog_commit_duration_seconds_bucket", cumulative, "writer", writer, "le", strconv.FormatFloat(bound, 'g', -1, 64))
+		}
+		cumulative += h.counts[len(c.buckets)].Load()
+		writeMetricsSample(w, "golog_commit_duration_seconds_bucket", cumulative, "writer", writer, "le", "+Inf")
+		writeMetricsSample(w, "golog_commit_duration_seconds_sum", time.Duration(h.sumNanos.Load()).Seconds(), "writer", writer)
+		writeMetricsSample(w, "golog_commit_duration_seconds_count", cumulative, "writer", writer)
+	}
+
+	if w.err == nil {
+		w.err = w.writer.Flush()
+	}
+	return w.n, w.err
+}
+
+// metricsTextWriter writes the Prometheus text exposition format
+// and remembers the number of written bytes and the first error.
+type metricsTextWriter struct {
+	writer *bufio.Writer
+	n      int64
+	err    error
+}
+
+func (w *metricsTextWriter) write(s string) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.writer.WriteString(s)
+	w.n += int64(n)
+	w.err = err
+}
+
+func (w *metricsTextWriter) header(name, typ, help string) {
+	w.write("# HELP " + name + " " + help + "\n# TYPE " + name + " " + typ + "\n")
+}
+
+// writeMetricsSample writes a metric line with alternating label names and values
+func writeMetricsSample[T uint64 | float64](w *metricsTextWriter, name string, value T, labels ...string) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(labels[i])
+			b.WriteString(`="`)
+			b.WriteString(metricsLabelReplacer.Replace(labels[i+1]))
+			b.WriteByte('"')
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	switch v := any(value).(type) {
+	case uint64:
+		b.WriteString(strconv.FormatUint(v, 10))
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	b.WriteByte('\n')
+	w.write(b.String())
+}
+
+var metricsLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -53,7 +53,8 @@ func (NopWriter) WriteString(val string) {}
 
 func (NopWriter) WriteError(val error) {}
 
-func (NopWriter) WriteTime(val time.Time) {}
+func (NopWriter) WriteTime(val time.Time)         {}
+func (NopWriter) WriteDuration(val time.Duration) {}
 
 func (NopWriter) WriteUUID(val [16]byte) {}
 
@@ -0,0 +1,168 @@
+package golog
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/domonda/go-encjson"
+)
+
+// maxLogValuerDepth limits the nesting of slog.LogValuer values
+// resolved to groups containing further LogValuer values.
+const maxLogValuerDepth = 16
+
+// logValuerPath holds the slog.LogValuer values being resolved
+// from the outermost to the innermost group.
+// Its length is the nesting depth.
+type logValuerPath []slog.LogValuer
+
+// push returns the path with v appended or an error value
+// if v is already being resolved or maxLogValuerDepth is reached.
+func (p logValuerPath) push(v slog.LogValuer) (logValuerPath, error) {
+	if len(p) >= maxLogValuerDepth {
+		return p, fmt.Errorf("LogValue nested deeper than %d for Value of type %T", maxLogValuerDepth, v)
+	}
+	if rv := reflect.ValueOf(v); rv.Comparable() {
+		for _, prev := range p {
+			if reflect.TypeOf(prev) == rv.Type() && reflect.ValueOf(prev).Equal(rv) {
+				return p, fmt.Errorf("LogValue cycle for Value of type %T", v)
+			}
+		}
+	}
+	return append(p, v), nil
+}
+
+// writeSlogValue writes a resolved slog.Value
+// with the Writer method matching its kind.
+// Groups are written as JSON objects.
+// The path holds the LogValuer values resolved to v.
+func (m *Message) writeSlogValue(w Writer, v slog.Value, path logValuerPath) {
+	switch v.Kind() {
+	case slog.KindString:
+		w.WriteString(v.String())
+	case slog.KindInt64:
+		w.WriteInt(v.Int64())
+	case slog.KindUint64:
+		w.WriteUint(v.Uint64())
+	case slog.KindFloat64:
+		w.WriteFloat(v.Float64())
+	case slog.KindBool:
+		w.WriteBool(v.Bool())
+	case slog.KindDuration:
+		writeAnyDuration(w, v.Duration())
+	case slog.KindTime:
+		w.WriteTime(v.Time())
+	case slog.KindGroup:
+		config := anyJSONConfigOf(w)
+		buf := encjson.AppendObjectStart(nil)
+		buf = appendSlogAttrsJSON(buf, v.Group(), path, &config)
+		w.WriteJSON(encjson.AppendObjectEnd(buf))
+	default:
+		val := v.Any()
+		if val == nil {
+			w.WriteNil()
+			return
+		}
+		m.writeAnyValue(w, reflect.ValueOf(val), false)
+	}
+}
+
+// appendSlogAttrsJSON appends the attrs as JSON object members
+// with the values formatted like Message.Any does.
+// Groups with an empty key are inlined like slog handlers do.
+// LogValuer values already in path or nested deeper
+// than maxLogValuerDepth are appended as error values.
+// The values are formatted with config, nil uses the default Format.
+func appendSlogAttrsJSON(buf []byte, attrs []slog.Attr, path logValuerPath, config *anyJSONConfig) []byte {
+	for _, attr := range attrs {
+		val := attr.Value
+		valPath := path
+		if val.Kind() == slog.KindLogValuer {
+			var err error
+			valPath, err = path.push(val.LogValuer())
+			if err != nil {
+				buf = appendAnyJSON(buf, attr.Key, err, nil, config)
+				continue
+			}
+			val = val.Resolve()
+		}
+		if val.Kind() == slog.KindGroup {
+			if attr.Key == "" {
+				buf = appendSlogAttrsJSON(buf, val.Group(), valPath, config)
+				continue
+			}
+			buf = encjson.AppendObjectStart(encjson.AppendKey(buf, attr.Key))
+			buf = appendSlogAttrsJSON(buf, val.Group(), valPath, config)
+			buf = encjson.AppendObjectEnd(buf)
+			continue
+		}
+		buf = appendAnyJSON(buf, attr.Key, val.Any(), valPath, config)
+	}
+	return buf
+}
+
+// anyJSONWriterConfig is used by appendAnyJSON
+// for JSONWriters that are never committed.
+var anyJSONWriterConfig = JSONWriterConfig{format: NewDefaultFormat()}
+
+// anyJSONConfig holds the Format and Limits of the writer
+// that JSON values built by appendAnyJSON are written to.
+// The zero value uses the default Format without limits.
+type anyJSONConfig struct {
+	format    *Format
+	limits    *Limits
+	truncated *bool // Set to true if limits truncated a value
+}
+
+// anyJSONConfigWriter is implemented by writers that pass
+// their Format and Limits to the JSON values built for them
+// by appendAnyJSON, like the JSON objects of slog groups.
+type anyJSONConfigWriter interface {
+	anyJSONConfig(config *anyJSONConfig)
+}
+
+// anyJSONConfigOf returns the anyJSONConfig for JSON values written to w.
+func anyJSONConfigOf(w Writer) (config anyJSONConfig) {
+	if c, ok := w.(anyJSONConfigWriter); ok {
+		c.anyJSONConfig(&config)
+	}
+	return config
+}
+
+// appendAnyJSON appends key and val to buf exactly like
+// Message.Any would write them with a JSONWriter using
+// the Format and Limits of config.
+// A nil config uses the default Format without limits.
+// The path holds the LogValuer values resolved to val
+// or is nil if val is not part of a resolved LogValuer.
+func appendAnyJSON(buf []byte, key string, val any, path logValuerPath, config *anyJSONConfig) []byte {
+	writerConfig := &anyJSONWriterConfig
+	if config != nil && config.format != nil {
+		writerConfig = &JSONWriterConfig{format: config.format}
+	}
+	w := &JSONWriter{config: writerConfig, buf: buf}
+	var m Message
+	m.writersArray[0] = w
+	m.writers = m.writersArray[:1]
+	m.logValuers = path
+	if config == nil || !config.limits.IsActive() {
+		m.Any(key, val)
+		return w.buf
+	}
+	// MaxMessageBytes is enforced by the writer
+	// of the message for the whole JSON value
+	limits := *config.limits
+	limits.MaxMessageBytes = 0
+	if !limits.IsActive() {
+		m.Any(key, val)
+		return w.buf
+	}
+	lw := &limitsWriter{wrapped: w, limits: &limits}
+	m.writersArray[0] = lw
+	m.Any(key, val)
+	if lw.truncated && config.truncated != nil {
+		*config.truncated = true
+	}
+	return w.buf
+}
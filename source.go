@@ -0,0 +1,172 @@
+package golog
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-encjson"
+)
+
+// SourceKey is the key used for the source location
+// of messages logged by a Logger with source capture enabled.
+// See Logger.WithSource
+const SourceKey = "source"
+
+// sourceWrapperPrefixes are the function name prefixes of call frames
+// that are skipped when capturing the source location of a message
+// because they are wrappers of the actual logging call.
+// Every prefix ends at a package or type boundary.
+var sourceWrapperPrefixes = []string{
+	"github.com/domonda/golog.(*Logger).",
+	"github.com/domonda/golog.(*LevelWriter).",
+	"github.com/domonda/golog.(*LevelDetectingWriter).",
+	"github.com/domonda/golog/log.",
+	"github.com/domonda/golog/goslog.(*handler).",
+	"github.com/domonda/golog/gozap.(*core).",
+	"github.com/domonda/golog/gozerolog.(*Writer).",
+	"github.com/domonda/golog/gologrus.(*Hook).",
+	"github.com/domonda/golog/gologrus.(*Formatter).",
+	"github.com/domonda/golog/gologrus.logEntry",
+	"log.",
+	"log/slog.",
+	"go.uber.org/zap.",
+	"go.uber.org/zap/",
+	"github.com/rs/zerolog.",
+	"github.com/rs/zerolog/",
+	"github.com/sirupsen/logrus.",
+}
+
+// Source is the source code location of a logging call.
+type Source struct {
+	// Function is the package path-qualified function name
+	Function string
+	// File is the absolute path of the source file
+	File string
+	// Line is the line number in File
+	Line int
+}
+
+// SourceFromPC returns the Source for a program counter
+// as returned by runtime.Callers or stored in slog.Record.PC.
+func SourceFromPC(pc uintptr) Source {
+	if pc == 0 {
+		return Source{}
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return Source{Function: frame.Function, File: frame.File, Line: frame.Line}
+}
+
+// maxCallerFrames is the number of call frames captured
+// to find the first caller outside of logging wrappers.
+const maxCallerFrames = 16
+
+// callerPCs holds the program counters of the call stack
+// of a logging call that are only resolved to a Source
+// when the source is actually written.
+type callerPCs struct {
+	pcs [maxCallerFrames]uintptr
+	n   int
+}
+
+// capture stores the program counters of the caller's stack
+// after skipping skip frames not counting capture itself.
+func (c *callerPCs) capture(skip int) {
+	c.n = runtime.Callers(skip+2, c.pcs[:])
+}
+
+// source resolves the Source of the first caller
+// outside of golog logging methods and wrappers
+// after skipping skip additional frames.
+// Returns a zero Source if no program counters were captured.
+func (c *callerPCs) source(skip int) Source {
+	if c.n == 0 {
+		return Source{}
+	}
+	frames := runtime.CallersFrames(c.pcs[:c.n])
+	for {
+		frame, more := frames.Next()
+		if !isSourceWrapperFrame(frame.Function) {
+			if skip <= 0 {
+				return Source{Function: frame.Function, File: frame.File, Line: frame.Line}
+			}
+			skip--
+		}
+		if !more {
+			return Source{}
+		}
+	}
+}
+
+func isSourceWrapperFrame(function string) bool {
+	for _, prefix := range sourceWrapperPrefixes {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero returns true if the Source has no location.
+func (s Source) IsZero() bool {
+	return s.File == "" && s.Line == 0
+}
+
+// String returns the source location as "file:line".
+func (s Source) String() string {
+	return s.File + ":" + strconv.Itoa(s.Line)
+}
+
+// AppendJSON appends the Source as JSON object
+// in the format written by JSONWriter to buf.
+func (s Source) AppendJSON(buf []byte) []byte {
+	buf = encjson.AppendObjectStart(buf)
+	buf = encjson.AppendKey(buf, "function")
+	buf = encjson.AppendString(buf, s.Function)
+	buf = encjson.AppendKey(buf, "file")
+	buf = encjson.AppendString(buf, s.File)
+	buf = encjson.AppendKey(buf, "line")
+	buf = encjson.AppendInt(buf, int64(s.Line))
+	return encjson.AppendObjectEnd(buf)
+}
+
+// MarshalJSON implements encoding/json.Marshaler
+// using the format of AppendJSON.
+func (s Source) MarshalJSON() ([]byte, error) {
+	return s.AppendJSON(nil), nil
+}
+
+// SourceWriter can be implemented by a Writer
+// to write a Source in a structured way
+// instead of the "file:line" string written by WriteString.
+type SourceWriter interface {
+	WriteSource(source Source)
+}
+
+func writeSource(w Writer, source Source) {
+	if sw, ok := w.(SourceWriter); ok {
+		sw.WriteSource(source)
+	} else {
+		w.WriteString(source.String())
+	}
+}
+
+// Source logs the passed source code location with the passed key.
+// Writers implementing SourceWriter like JSONWriter and TextWriter
+// write the location structured, all other writers
+// write it as string in the format "file:line".
+// A zero Source is not logged.
+func (m *Message) Source(key string, source Source) *Message {
+	if source.IsZero() || m.skipKey(&key) {
+		return m
+	}
+	if m.IsAttribRecorder() {
+		m.attribs.Add(NewAny(key, source))
+		return m
+	}
+	for _, w := range m.writers {
+		w.WriteKey(key)
+		writeSource(w, source)
+	}
+	return m
+}
@@ -0,0 +1,115 @@
+package golog
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// previousLineSource returns the Source
+// of the line before the caller's line.
+func previousLineSource() Source {
+	pc, file, line, _ := runtime.Caller(1)
+	return Source{Function: runtime.FuncForPC(pc).Name(), File: file, Line: line - 1}
+}
+
+func logWrapped(log *Logger, text string) {
+	log.Info(text).Log()
+}
+
+func TestLogger_WithSource(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewTextWriterConfig(&buf, &Format{}, NoColorizer))
+	log := NewLogger(config)
+
+	log.Info("No source").Log()
+	assert.Equal(t, " |INFO | No source\n", buf.String())
+	assert.False(t, log.SourceEnabled())
+
+	log = log.WithSource(0)
+	assert.True(t, log.SourceEnabled())
+
+	buf.Reset()
+	log.Info("With source").Int("n", 1).Log()
+	source := previousLineSource()
+	assert.Equal(t, " |INFO | With source source="+source.File+":"+strconv.Itoa(source.Line)+" n=1\n", buf.String())
+
+	buf.Reset()
+	log.With().Str("sub", "x").SubLogger().Infof("Formatted %d", 1).Log()
+	source = previousLineSource()
+	assert.Contains(t, buf.String(), "source="+source.String()+" sub=")
+
+	buf.Reset()
+	logWrapped(log.WithSource(1), "Skip wrapper")
+	source = previousLineSource()
+	assert.Contains(t, buf.String(), "source="+source.String())
+
+	buf.Reset()
+	log.WithoutSource().Info("No source").Log()
+	assert.Equal(t, " |INFO | No source\n", buf.String())
+}
+
+func TestLogger_NewMessageWithSource(t *testing.T) {
+	var buf bytes.Buffer
+	config := NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{}))
+	source := Source{Function: "pkg.Func", File: "/src/pkg/file.go", Line: 42}
+
+	NewLogger(config).NewMessageWithSource(t.Context(), time.Time{}, DefaultLevels.Info, "", source).Log()
+
+	var written struct {
+		Source Source `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &written), buf.String())
+	assert.Equal(t, source, written.Source)
+	assert.Equal(t, `{"source":{"function":"pkg.Func","file":"/src/pkg/file.go","line":42}}`+"\n", buf.String())
+}
+
+func TestSourceFromPC(t *testing.T) {
+	assert.True(t, SourceFromPC(0).IsZero())
+
+	pc, file, line, _ := runtime.Caller(0)
+	source := SourceFromPC(pc)
+	assert.Equal(t, file, source.File)
+	assert.Equal(t, line, source.Line)
+	assert.Equal(t, "github.com/domonda/golog.TestSourceFromPC", source.Function)
+}
+
+func TestLogger_WithSource_keyCollision(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{}))).WithSource(0)
+
+	log.Info("").Str(SourceKey, "user").Log()
+	source := previousLineSource()
+	assert.Equal(t, `{"source":`+string(source.AppendJSON(nil))+"}\n", buf.String())
+
+	buf.Reset()
+	log.WithAttribKeyCollision(AttribCollisionLastWins).Info("").Str(SourceKey, "user").Log()
+	assert.Equal(t, `{"source":"user"}`+"\n", buf.String())
+}
+
+func TestIsSourceWrapperFrame(t *testing.T) {
+	for function, want := range map[string]bool{
+		"github.com/domonda/golog.(*Logger).Info":               true,
+		"github.com/domonda/golog.TestLogger_WithSource":        false,
+		"github.com/domonda/golog/goslog.(*handler).Handle":     true,
+		"github.com/domonda/golog/goslog.TestHandler":           false,
+		"go.uber.org/zap.(*Logger).Info":                        true,
+		"go.uber.org/zap/zapcore.(*CheckedEntry).Write":         true,
+		"go.uber.org/zapper.Func":                               false,
+		"github.com/rs/zerolog.(*Event).Msg":                    true,
+		"github.com/rs/zerologger.Func":                         false,
+		"github.com/sirupsen/logrus.(*Entry).Info":              true,
+		"github.com/domonda/golog/gologrus.logEntry":            true,
+		"github.com/domonda/golog/gologrus.TestHook_WithSource": false,
+		"log/slog.(*Logger).Info":                               true,
+		"logger.Func":                                           false,
+	} {
+		assert.Equal(t, want, isSourceWrapperFrame(function), function)
+	}
+}
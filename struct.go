@@ -1,8 +1,10 @@
 package golog
 
 import (
+	"go/token"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 // Interface types for the IsNull and IsZero method checks used by the
@@ -11,8 +13,19 @@ import (
 var (
 	isNullIfaceType = reflect.TypeOf((*interface{ IsNull() bool })(nil)).Elem()
 	isZeroIfaceType = reflect.TypeOf((*interface{ IsZero() bool })(nil)).Elem()
+	loggableType    = reflect.TypeFor[Loggable]()
 )
 
+// FlattenStructFields makes StructFields and TaggedStructFields
+// log the fields of all nested structs and the entries of maps
+// with string keys with dotted keys like "address.city"
+// as if all fields had the flatten modifier.
+var FlattenStructFields = false
+
+// MaxStructFieldsDepth limits the nesting depth of flattened values.
+// Deeper nested values are logged with Message.Any.
+var MaxStructFieldsDepth = 10
+
 // structFieldDirectives is the parsed result of a struct tag value such as
 // "name,omitempty,redact" — see parseStructFieldDirectives.
 type structFieldDirectives struct {
@@ -22,6 +35,8 @@ type structFieldDirectives struct {
 	omitempty bool
 	omitzero  bool
 	omitnull  bool
+	flatten   bool
+	inline    bool
 }
 
 // parseStructFieldDirectives parses a raw struct tag value (the string after
@@ -32,8 +47,8 @@ type structFieldDirectives struct {
 // Rules:
 //   - Bare "-" (no comma) → skip the field.
 //   - Empty name ("" or ",...") → key left empty; caller substitutes field.Name.
-//   - Recognized modifiers: omitempty, omitzero, omitnull, redact (also spelled
-//     "redacted" for the redact modifier).
+//   - Recognized modifiers: omitempty, omitzero, omitnull, flatten, inline,
+//     redact (also spelled "redacted" for the redact modifier).
 //   - Unknown modifier tokens are ignored silently (matches encoding/json).
 //   - Whitespace around the name and each modifier is trimmed.
 func parseStructFieldDirectives(value string) structFieldDirectives {
@@ -60,6 +75,10 @@ func parseStructFieldDirectives(value string) structFieldDirectives {
 			d.omitzero = true
 		case "omitnull":
 			d.omitnull = true
+		case "flatten":
+			d.flatten = true
+		case "inline":
+			d.inline = true
 		}
 	}
 	return d
@@ -197,3 +216,154 @@ func isEmptyValueForOmitempty(fv reflect.Value) bool {
 	}
 	return false
 }
+
+// structPlanKey identifies the structPlan of a struct type
+// for a comma separated list of key tags.
+type structPlanKey struct {
+	t       reflect.Type
+	keyTags string
+}
+
+// structFieldPlan is the compiled logging plan for a struct field
+// with its index, parsed directives, and optional typed write function.
+type structFieldPlan struct {
+	structFieldDirectives
+
+	index     int
+	fieldType reflect.Type
+	embedded  bool // anonymous struct field whose fields are logged
+
+	// write logs the field value without the allocation of
+	// reflect.Value.Interface for predeclared basic types.
+	// nil if the value has to be logged with Message.Any.
+	write func(m *Message, key string, fv reflect.Value)
+}
+
+// structPlan holds the fields of a struct type to be logged in order
+type structPlan []structFieldPlan
+
+var structPlans sync.Map // map[structPlanKey]structPlan
+
+// structPlanFor returns the cached structPlan for t and keyTags
+// or compiles and caches it on the first call.
+func structPlanFor(t reflect.Type, keyTags string) structPlan {
+	key := structPlanKey{t: t, keyTags: keyTags}
+	if plan, ok := structPlans.Load(key); ok {
+		return plan.(structPlan)
+	}
+	plan, _ := structPlans.LoadOrStore(key, compileStructPlan(t, strings.Split(keyTags, ",")))
+	return plan.(structPlan)
+}
+
+func compileStructPlan(t reflect.Type, keyTags []string) structPlan {
+	var plan structPlan
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Pointer {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				plan = append(plan, structFieldPlan{index: i, embedded: true})
+			}
+			continue
+		}
+		if !token.IsExported(field.Name) {
+			continue
+		}
+
+		// Find the first keyTag that "matches" this field. Lookup (not
+		// Get) so that an explicit empty tag value like `json:""` counts
+		// as present.
+		//
+		// An empty string in keyTags is a wildcard: it matches every
+		// exported field with an empty raw tag value (which is
+		// substituted with the Go field name). This gives callers
+		// a way to dump a struct wholesale under Go field names, e.g.
+		// `TaggedStructFields(s, "")` for log-everything, or
+		// keyTags "json," for "prefer json tag, fall back
+		// to Go field name".
+		var rawTag string
+		var found bool
+		for _, kt := range keyTags {
+			if kt == "" {
+				found = true
+				rawTag = ""
+				break
+			}
+			if tv, ok := field.Tag.Lookup(kt); ok {
+				rawTag = tv
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		d := parseStructFieldDirectives(rawTag)
+		if d.skip {
+			continue
+		}
+		if d.key == "" {
+			d.key = field.Name
+		}
+		plan = append(plan, structFieldPlan{
+			structFieldDirectives: d,
+			index:                 i,
+			fieldType:             field.Type,
+			write:                 structFieldWriteFunc(field.Type),
+		})
+	}
+	return plan
+}
+
+// structFieldWriteFunc returns a function logging values of type t
+// with the typed Message method that Message.Any would end up using,
+// or nil if t is not a predeclared basic type.
+// Named types are excluded because they might implement
+// interfaces like Loggable that Message.Any checks for.
+func structFieldWriteFunc(t reflect.Type) func(m *Message, key string, fv reflect.Value) {
+	if t.PkgPath() != "" || t.Name() == "" {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return func(m *Message, key string, fv reflect.Value) { m.Bool(key, fv.Bool()) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(m *Message, key string, fv reflect.Value) { m.Int64(key, fv.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(m *Message, key string, fv reflect.Value) { m.Uint64(key, fv.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		return func(m *Message, key string, fv reflect.Value) { m.Float(key, fv.Float()) }
+	case reflect.String:
+		return func(m *Message, key string, fv reflect.Value) { m.Str(key, fv.String()) }
+	}
+	return nil
+}
+
+// isFlattenableType returns true if values of type t can be
+// logged by flattening their struct fields or map entries
+// because t is a struct, a map with string keys, or an interface
+// or pointer that might hold one, and t does not log itself
+// with one of the interfaces or encoders checked by Message.Any.
+func isFlattenableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return true // Dynamic type is checked by the caller
+	case reflect.Pointer:
+		if !isFlattenableType(t.Elem()) {
+			return false
+		}
+	case reflect.Struct:
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return false
+		}
+	default:
+		return false
+	}
+	return !isSingleValueType(t) && !t.Implements(loggableType) && !t.Implements(isNullIfaceType)
+}
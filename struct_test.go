@@ -1,6 +1,7 @@
 package golog
 
 import (
+	"bytes"
 	"encoding/json"
 	"reflect"
 	"testing"
@@ -29,6 +30,8 @@ func TestParseStructFieldDirectives(t *testing.T) {
 		{"name with omitnull", "name,omitnull", structFieldDirectives{key: "name", omitnull: true}},
 		{"name with redact", "name,redact", structFieldDirectives{key: "name", redact: true}},
 		{"name with redacted alias", "name,redacted", structFieldDirectives{key: "name", redact: true}},
+		{"name with flatten", "name,flatten", structFieldDirectives{key: "name", flatten: true}},
+		{"empty name with inline", ",inline", structFieldDirectives{inline: true}},
 		{"empty name with omitempty", ",omitempty", structFieldDirectives{omitempty: true}},
 		{"all four modifiers", "name,redact,omitempty,omitzero,omitnull", structFieldDirectives{
 			key: "name", redact: true, omitempty: true, omitzero: true, omitnull: true,
@@ -259,3 +262,126 @@ func TestEncodingJSONIgnoresCustomModifiers(t *testing.T) {
 	// D has omitempty too — encoding/json suppresses it when empty.
 	assert.NotContains(t, got, `"d":`)
 }
+
+func TestStructPlanFor(t *testing.T) {
+	type Embedded struct {
+		E string `json:"e"`
+	}
+	type Named int
+	type S struct {
+		*Embedded
+		A       int    `json:"a"`
+		B       string `log:"b,redact"`
+		C       Named  `json:"c,omitempty"`
+		Skipped bool   `json:"-"`
+		private int
+		Slice   []string `golog:"slice"`
+	}
+	typ := reflect.TypeFor[S]()
+
+	plan := structPlanFor(typ, "golog,log,json")
+	if assert.Len(t, plan, 5) {
+		assert.True(t, plan[0].embedded)
+		assert.Equal(t, "a", plan[1].key)
+		assert.NotNil(t, plan[1].write, "predeclared int uses typed write")
+		assert.Equal(t, "b", plan[2].key)
+		assert.True(t, plan[2].redact)
+		assert.Equal(t, "c", plan[3].key)
+		assert.Nil(t, plan[3].write, "named type uses Message.Any")
+		assert.Equal(t, "slice", plan[4].key)
+		assert.Nil(t, plan[4].write)
+	}
+	assert.Equal(t, reflect.ValueOf(plan).Pointer(), reflect.ValueOf(structPlanFor(typ, "golog,log,json")).Pointer(), "plan is cached")
+	assert.Len(t, structPlanFor(typ, "json"), 3, "plans are cached per key tags")
+}
+
+func TestMessage_StructFields_flatten(t *testing.T) {
+	type Address struct {
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+	type Meta struct {
+		Source string `json:"source"`
+	}
+	type User struct {
+		Name     string            `json:"name"`
+		Address  Address           `json:"address,flatten"`
+		Previous *Address          `json:"previous,flatten"`
+		Labels   map[string]string `json:"labels,flatten"`
+		Meta     Meta              `json:"meta,inline"`
+		Created  time.Time         `json:"created,flatten"`
+		Other    Address           `json:"other"`
+	}
+	user := User{
+		Name:     "Alice",
+		Address:  Address{City: "Vienna", Country: "AT"},
+		Previous: &Address{City: "Graz"},
+		Labels:   map[string]string{"team": "core", "role": "admin"},
+		Meta:     Meta{Source: "api"},
+		Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Other:    Address{City: "Linz"},
+	}
+
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})))
+
+	log.Info("").StructFields(user).Log()
+	assert.Equal(t, `{"name":"Alice","address.city":"Vienna","address.country":"AT","previous.city":"Graz","previous.country":"",`+
+		`"labels.role":"admin","labels.team":"core","source":"api","created":"2024-01-02T03:04:05Z","other":{"city":"Linz","country":""}}`+"\n",
+		buf.String(),
+	)
+
+	t.Run("FlattenStructFields", func(t *testing.T) {
+		FlattenStructFields = true
+		t.Cleanup(func() { FlattenStructFields = false })
+
+		buf.Reset()
+		log.Info("").StructFields(struct {
+			Other Address        `json:"other"`
+			Any   map[string]any `json:"any"`
+		}{
+			Other: Address{City: "Linz", Country: "AT"},
+			Any:   map[string]any{"nested": Address{City: "Wels"}, "nil": nil, "n": 1},
+		}).Log()
+		assert.Equal(t, `{"other.city":"Linz","other.country":"AT","any.n":1,"any.nested.city":"Wels","any.nested.country":"","any.nil":null}`+"\n", buf.String())
+	})
+}
+
+func TestMessage_StructFields_flatten_limits(t *testing.T) {
+	type Node struct {
+		Name string `json:"name"`
+		Next *Node  `json:"next,flatten"`
+	}
+	var buf bytes.Buffer
+	log := NewLogger(NewConfig(&DefaultLevels, AllLevelsActive, NewJSONWriterConfig(&buf, &Format{})))
+
+	t.Run("cycle", func(t *testing.T) {
+		a := &Node{Name: "a"}
+		b := &Node{Name: "b", Next: a}
+		a.Next = b
+
+		buf.Reset()
+		log.Info("").StructFields(a).Log()
+		assert.Equal(t, `{"name":"a","next.name":"b","next.next":"<cycle>"}`+"\n", buf.String())
+
+		self := map[string]any{"x": 1}
+		self["self"] = self
+		FlattenStructFields = true
+		t.Cleanup(func() { FlattenStructFields = false })
+		buf.Reset()
+		log.Info("").StructFields(struct {
+			M map[string]any `json:"m"`
+		}{self}).Log()
+		assert.Equal(t, `{"m.self":"<cycle>","m.x":1}`+"\n", buf.String())
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		prevDepth := MaxStructFieldsDepth
+		MaxStructFieldsDepth = 2
+		t.Cleanup(func() { MaxStructFieldsDepth = prevDepth })
+
+		buf.Reset()
+		log.Info("").StructFields(&Node{Name: "1", Next: &Node{Name: "2", Next: &Node{Name: "3", Next: &Node{Name: "4"}}}}).Log()
+		assert.Equal(t, `{"name":"1","next.name":"2","next.next.name":"3","next.next.next":{"name":"4","next":null}}`+"\n", buf.String())
+	})
+}
@@ -2,7 +2,8 @@ package golog
 
 import "github.com/muesli/termenv"
 
-var _ Colorizer = new(StyledColorizer) // make sure StyledColorizer implements Colorizer
+var _ Colorizer = new(StyledColorizer)         // make sure StyledColorizer implements Colorizer
+var _ DurationColorizer = new(StyledColorizer) // make sure StyledColorizer implements DurationColorizer
 
 type StyledColorizer struct {
 	MsgStyle        termenv.Style
@@ -24,6 +25,7 @@ type StyledColorizer struct {
 	StringStyle     termenv.Style
 	ErrorStyle      termenv.Style
 	UUIDStyle       termenv.Style
+	DurationStyle   termenv.Style
 }
 
 func (c *StyledColorizer) ColorizeMsg(str string) string {
@@ -96,3 +98,8 @@ func (c *StyledColorizer) ColorizeError(str string) string {
 func (c *StyledColorizer) ColorizeUUID(str string) string {
 	return c.UUIDStyle.Styled(str)
 }
+
+// ColorizeDuration implements the DurationColorizer interface.
+func (c *StyledColorizer) ColorizeDuration(str string) string {
+	return c.DurationStyle.Styled(str)
+}
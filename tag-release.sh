@@ -4,7 +4,7 @@
 SCRIPT_DIR=$(cd -P -- $(dirname -- "$0") && pwd -P)
 cd $SCRIPT_DIR
 
-MODULE_PATHS=("" "goslog/" "logsentry/")
+MODULE_PATHS=("" "gologrus/" "goslog/" "gozap/" "gozerolog/" "logsentry/")
 
 # Show current tags and usage if no arguments provided
 if [ -z "$1" ]; then
@@ -37,7 +37,7 @@ if [ -z "$1" ]; then
     echo "Creates tags for all modules with the specified version."
     echo ""
     echo "Examples:"
-    echo "  $0 v0.99.1               # Creates v0.99.1, goslog/v0.99.1, logsentry/v0.99.1"
+    echo "  $0 v0.99.1               # Creates v0.99.1, goslog/v0.99.1, logsentry/v0.99.1, ..."
     echo "  $0 v0.99.1 \"bug fixes\"   # Same with custom message"
     echo "  $0 v1.0.0-beta1          # Pre-release version"
     echo ""
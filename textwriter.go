@@ -19,8 +19,11 @@ const (
 )
 
 var (
-	_ Writer       = new(TextWriter)
-	_ WriterConfig = new(TextWriterConfig)
+	_ Writer            = new(TextWriter)
+	_ ErrorChainWriter  = new(TextWriter)
+	_ SourceWriter      = new(TextWriter)
+	_ CommitErrorWriter = new(TextWriter)
+	_ WriterConfig      = new(TextWriterConfig)
 )
 
 type TextWriterConfig struct {
@@ -52,6 +55,12 @@ func NewTextWriterConfig(writer io.Writer, format *Format, colorizer Colorizer,
 	}
 }
 
+// IsActive implements the LevelDecider interface
+// by returning if the filter of the config passes the level.
+func (c *TextWriterConfig) IsActive(ctx context.Context, level Level) bool {
+	return c.filter.IsActive(ctx, level)
+}
+
 func (c *TextWriterConfig) WriterForNewMessage(ctx context.Context, level Level) Writer {
 	if c.filter.IsInactive(ctx, level) {
 		return nil
@@ -61,7 +70,7 @@ func (c *TextWriterConfig) WriterForNewMessage(ctx context.Context, level Level)
 	if w.buf == nil {
 		w.buf = make([]byte, 0, 1024)
 	}
-	return w
+	return LimitWriter(w, &c.format.Limits)
 }
 
 func (c *TextWriterConfig) FlushUnderlying() {
@@ -120,11 +129,19 @@ func (w *TextWriter) BeginMessage(config Config, timestamp time.Time, level Leve
 }
 
 func (w *TextWriter) CommitMessage() {
+	if err := w.CommitMessageError(); err != nil && ErrorHandler != nil {
+		ErrorHandler(err)
+	}
+}
+
+// CommitMessageError commits the message like CommitMessage
+// but returns the error of writing it instead of passing it to ErrorHandler.
+func (w *TextWriter) CommitMessageError() (err error) {
 	// Flush w.buf
 	if len(w.buf) > 0 {
-		_, err := w.config.writer.Write(append(w.buf, '\n'))
-		if err != nil && ErrorHandler != nil {
-			ErrorHandler(fmt.Errorf("golog.TextWriter error: %w", err))
+		_, err = w.config.writer.Write(append(w.buf, '\n'))
+		if err != nil {
+			err = fmt.Errorf("golog.TextWriter error: %w", err)
 		}
 	}
 
@@ -133,12 +150,18 @@ func (w *TextWriter) CommitMessage() {
 	w.sliceMode = sliceModeNone
 	w.buf = w.buf[:0]
 	textWriterPool.PutBack(w)
+	return err
 }
 
 func (w *TextWriter) String() string {
 	return string(w.buf)
 }
 
+// bufferLen implements bufferWriter
+func (w *TextWriter) bufferLen() int {
+	return len(w.buf)
+}
+
 func (w *TextWriter) WriteKey(key string) {
 	str := w.config.colorizer.ColorizeKey(key)
 	w.buf = append(w.buf, ' ')
@@ -244,8 +267,14 @@ func (w *TextWriter) WriteError(val error) {
 		return
 	}
 	w.writeSliceSep()
+	w.writeErrorText(val.Error())
+}
 
-	lines := strings.Split(val.Error(), "\n")
+// writeErrorText writes an error text in backticks
+// starting multi-line texts on a new line
+// with every line colorized separately.
+func (w *TextWriter) writeErrorText(text string) {
+	lines := strings.Split(text, "\n")
 	if len(lines) == 1 {
 		w.buf = append(w.buf, '`')
 		w.buf = append(w.buf, w.config.colorizer.ColorizeError(lines[0])...)
@@ -260,6 +289,46 @@ func (w *TextWriter) WriteError(val error) {
 	}
 }
 
+func (w *TextWriter) WriteErrorChain(chain *ErrorChain) {
+	w.writeSliceSep()
+	// Attribs of the chain are written as nested values
+	// so the slice mode of the message has to be restored
+	mode := w.sliceMode
+	w.sliceMode = sliceModeNone
+	w.writeErrorChain(chain)
+	w.sliceMode = mode
+}
+
+func (w *TextWriter) writeErrorChain(chain *ErrorChain) {
+	w.writeErrorText(chain.Message)
+	w.buf = append(w.buf, " ("...)
+	w.buf = append(w.buf, chain.Type...)
+	writeErrorChainAttribs(w, chain.Attribs)
+	w.buf = append(w.buf, ')')
+	switch len(chain.Causes) {
+	case 0:
+	case 1:
+		w.buf = append(w.buf, " <- "...)
+		w.writeErrorChain(chain.Causes[0])
+	default:
+		w.buf = append(w.buf, " <- ["...)
+		for i, cause := range chain.Causes {
+			if i > 0 {
+				w.buf = append(w.buf, ", "...)
+			}
+			w.writeErrorChain(cause)
+		}
+		w.buf = append(w.buf, ']')
+	}
+}
+
+func (w *TextWriter) WriteSource(source Source) {
+	w.writeSliceSep()
+	w.buf = append(w.buf, source.File...)
+	w.buf = append(w.buf, ':')
+	w.buf = strconv.AppendInt(w.buf, int64(source.Line), 10)
+}
+
 func (w *TextWriter) WriteTime(val time.Time) {
 	w.writeSliceSep()
 	format := w.config.format.TimeFormat
@@ -280,6 +349,47 @@ func (w *TextWriter) WriteTime(val time.Time) {
 	}
 }
 
+func (w *TextWriter) WriteDuration(val time.Duration) {
+	w.writeSliceSep()
+	format := w.config.format.DurationFormat
+	if w.config.noColorizer {
+		w.buf = appendTextDuration(w.buf, format, val)
+	} else {
+		str := string(appendTextDuration(nil, format, val))
+		if c, ok := w.config.colorizer.(DurationColorizer); ok {
+			str = c.ColorizeDuration(str)
+		} else if format.IsNumber() {
+			str = w.config.colorizer.ColorizeFloat(str)
+		} else {
+			str = w.config.colorizer.ColorizeString(str)
+		}
+		w.buf = append(w.buf, str...)
+	}
+}
+
+// appendTextDuration appends val formatted according to format
+// with string formats quoted like other strings.
+func appendTextDuration(buf []byte, format DurationFormat, val time.Duration) []byte {
+	if format.IsNumber() {
+		return format.AppendDuration(buf, val)
+	}
+	buf = append(buf, '"')
+	buf = format.AppendDuration(buf, val)
+	return append(buf, '"')
+}
+
+func (w *TextWriter) anyJSONConfig(config *anyJSONConfig) {
+	config.format = w.config.format
+}
+
+func (w *TextWriter) writeAnyDuration(val time.Duration) {
+	if w.config.format.DurationFormat == "" {
+		w.WriteInt(int64(val))
+		return
+	}
+	w.WriteDuration(val)
+}
+
 // func (f *TextFormatter) WriteBytes(val []byte) {
 // 	w.writeSliceSep()
 // 	hexVal := make([]byte, len(val)*2+2)
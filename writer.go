@@ -34,6 +34,7 @@ type Writer interface {
 	WriteString(string)
 	WriteError(error)
 	WriteTime(time.Time)
+	WriteDuration(time.Duration)
 	WriteUUID([16]byte)
 	WriteJSON([]byte)
 	// WritePtr(uintptr)
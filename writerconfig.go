@@ -25,6 +25,17 @@ type WriterConfig interface {
 	FlushUnderlying()
 }
 
+// WriterConfigIsActive returns false if config implements LevelDecider
+// and decides that level is not active for ctx.
+// WriterConfigs wrapping other configs can use it to check
+// if the wrapped config would write a message before recording it.
+func WriterConfigIsActive(ctx context.Context, config WriterConfig, level Level) bool {
+	if decider, ok := config.(LevelDecider); ok {
+		return decider.IsActive(ctx, level)
+	}
+	return true
+}
+
 var writerConfigsCtxKey int
 
 // ContextWithAdditionalWriterConfigs returns a context